@@ -24,7 +24,10 @@ func NewAll() *All {
 
 // UnmarshalBinary turns bytes into struct.
 func (a *All) UnmarshalBinary(b []byte) error {
-	return a.header.unmarshalBinary(b)
+	if err := a.header.unmarshalBinary(b); err != nil {
+		return err
+	}
+	return a.header.checkLength(0)
 }
 
 // MarshalBinary converts struct into byte slice.
@@ -2,6 +2,9 @@ package block
 
 import (
 	"encoding"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
 )
 
 // Block interface.
@@ -10,3 +13,9 @@ type Block interface {
 	encoding.BinaryMarshaler
 	encoding.BinaryUnmarshaler
 }
+
+// Typer is implemented by blocks that know their option and suboption. All
+// blocks of this package implement it through their block header.
+type Typer interface {
+	Type() (option.Option, suboption.Suboption)
+}
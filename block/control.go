@@ -0,0 +1,79 @@
+package block
+
+import (
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// ResetMode is the block qualifier of a reset to factory block.
+type ResetMode uint16
+
+// Known reset modes.
+const (
+	ResetApplicationData ResetMode = 0x0002
+	ResetCommunication   ResetMode = 0x0004
+	ResetEngineering     ResetMode = 0x0006
+	ResetAllData         ResetMode = 0x0008
+	ResetDevice          ResetMode = 0x0010
+)
+
+// Control is a control block without data like start and stop.
+type Control struct {
+	header
+}
+
+var _ Block = &Control{}
+
+// NewStart returns a new block that starts a set transaction.
+func NewStart() *Control {
+	return newControl(suboption.Start)
+}
+
+// NewStop returns a new block that ends a set transaction.
+func NewStop() *Control {
+	return newControl(suboption.Stop)
+}
+
+// NewFactoryReset returns a new block that resets the device to its factory
+// settings.
+func NewFactoryReset() *Control {
+	return newControl(suboption.FactoryReset)
+}
+
+// NewResetToFactory returns a new block that resets the data selected by
+// mode to its factory values.
+func NewResetToFactory(mode ResetMode) *Control {
+	c := newControl(suboption.ResetToFactory)
+	c.Qualifier = uint16(mode)
+	return c
+}
+
+func newControl(subopt suboption.Suboption) *Control {
+	return &Control{
+		header: header{
+			Option:       option.Control,
+			Suboption:    subopt,
+			Length:       2,
+			HasInfo:      false,
+			HasQualifier: true,
+		},
+	}
+}
+
+// UnmarshalBinary turns bytes into struct.
+func (c *Control) UnmarshalBinary(b []byte) error {
+	if err := c.header.unmarshalBinary(b); err != nil {
+		return err
+	}
+	return c.header.checkLength(0)
+}
+
+// MarshalBinary converts struct into byte slice.
+func (c *Control) MarshalBinary() ([]byte, error) {
+	return c.header.marshalBinary()
+}
+
+// Len returns length for control block.
+func (c *Control) Len() int {
+	return c.header.len()
+}
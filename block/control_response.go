@@ -10,7 +10,7 @@ type ControlResponse struct {
 	header
 	Response  option.Option
 	Suboption suboption.Suboption
-	Error     uint8
+	Error     BlockError
 }
 
 var _ Block = &ControlResponse{}
@@ -24,11 +24,41 @@ func NewControlResponse(hasInfo bool) *ControlResponse {
 	}
 }
 
+// NewControlResponseWithError returns a new block that answers a set request
+// block with the given option and suboption.
+func NewControlResponseWithError(opt option.Option, subopt suboption.Suboption, code BlockError) *ControlResponse {
+	return &ControlResponse{
+		header: header{
+			Option:    option.Control,
+			Suboption: suboption.Response,
+			Length:    3,
+		},
+		Response:  opt,
+		Suboption: subopt,
+		Error:     code,
+	}
+}
+
+// Err returns an error if the response carries an error code.
+func (c *ControlResponse) Err() error {
+	if c.Error == NoError {
+		return nil
+	}
+	return &ResponseError{
+		Option:    c.Response,
+		Suboption: c.Suboption,
+		Code:      c.Error,
+	}
+}
+
 // UnmarshalBinary turns bytes into struct.
 func (c *ControlResponse) UnmarshalBinary(b []byte) error {
 	if err := c.header.unmarshalBinary(b); err != nil {
 		return err
 	}
+	if err := c.header.checkLength(3); err != nil {
+		return err
+	}
 
 	offset := c.header.len()
 
@@ -38,7 +68,7 @@ func (c *ControlResponse) UnmarshalBinary(b []byte) error {
 	c.Suboption = suboption.Suboption(b[offset])
 	offset++
 
-	c.Error = b[offset]
+	c.Error = BlockError(b[offset])
 
 	return nil
 }
@@ -62,7 +92,7 @@ func (c *ControlResponse) MarshalBinary() ([]byte, error) {
 	b[offset] = byte(c.Suboption)
 	offset++
 
-	b[offset] = c.Error
+	b[offset] = byte(c.Error)
 
 	return b, nil
 }
@@ -0,0 +1,101 @@
+package block
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+func TestControlResponseUnmarshalBinary(t *testing.T) {
+	b := []byte{0x05, 0x04, 0x00, 0x03, 0x02, 0x02, 0x06}
+
+	c := NewControlResponse(false)
+	if err := c.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	}
+	if c.Response != option.Properties {
+		t.Errorf("expected %d; got %d", option.Properties, c.Response)
+	}
+	if c.Suboption != suboption.NameOfStation {
+		t.Errorf("expected %d; got %d", suboption.NameOfStation, c.Suboption)
+	}
+	if c.Error != InOperation {
+		t.Errorf("expected %d; got %d", InOperation, c.Error)
+	}
+}
+
+func TestControlResponseMarshalBinary(t *testing.T) {
+	c := NewControlResponseWithError(option.Properties, suboption.NameOfStation, InOperation)
+	b, err := c.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	expected := []byte{0x05, 0x04, 0x00, 0x03, 0x02, 0x02, 0x06}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestControlResponseErr(t *testing.T) {
+	tests := []struct {
+		name string
+		code BlockError
+		is   error
+		err  string
+	}{
+		{
+			name: "no error",
+			code: NoError,
+		},
+		{
+			name: "option not supported",
+			code: OptionNotSupported,
+			is:   ErrOptionNotSupported,
+			err:  "block: option not supported for option 0x02 suboption 0x02",
+		},
+		{
+			name: "in operation",
+			code: InOperation,
+			is:   ErrInOperation,
+			err:  "block: in operation, set not possible for option 0x02 suboption 0x02",
+		},
+		{
+			name: "unknown",
+			code: 0x42,
+			err:  "block: unknown error 0x42 for option 0x02 suboption 0x02",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ControlResponse{
+				Response:  option.Properties,
+				Suboption: suboption.NameOfStation,
+				Error:     tt.code,
+			}
+			err := c.Err()
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("expected no error; got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("expected %s; got %v", tt.err, err)
+			}
+			if tt.is != nil && !errors.Is(err, tt.is) {
+				t.Errorf("expected %v to be %v", err, tt.is)
+			}
+			if errors.Is(err, ErrResource) {
+				t.Errorf("expected %v not to be %v", err, ErrResource)
+			}
+			var re *ResponseError
+			if !errors.As(err, &re) || re.Suboption != suboption.NameOfStation {
+				t.Errorf("expected response error for name of station; got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package block
+
+import (
+	"encoding/binary"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// SignalFlashOnce lets the device flash its signal LED once.
+const SignalFlashOnce uint16 = 0x0100
+
+// Signal is a control signal block.
+type Signal struct {
+	header
+	Value uint16
+}
+
+var _ Block = &Signal{}
+
+// NewSignal returns a new block that lets the device flash once.
+func NewSignal() *Signal {
+	return &Signal{
+		header: header{
+			Option:       option.Control,
+			Suboption:    suboption.Signal,
+			Length:       4,
+			HasInfo:      false,
+			HasQualifier: true,
+		},
+		Value: SignalFlashOnce,
+	}
+}
+
+// UnmarshalBinary turns bytes into struct.
+func (s *Signal) UnmarshalBinary(b []byte) error {
+	if err := s.header.unmarshalBinary(b); err != nil {
+		return err
+	}
+	if err := s.header.checkLength(2); err != nil {
+		return err
+	}
+
+	i := s.header.len()
+	s.Value = binary.BigEndian.Uint16(b[i : i+2])
+
+	return nil
+}
+
+// MarshalBinary converts struct into byte slice.
+func (s *Signal) MarshalBinary() ([]byte, error) {
+	b := make([]byte, s.Len())
+
+	bh, err := s.header.marshalBinary()
+	if err != nil {
+		return b, err
+	}
+	offset := 0
+
+	copy(b[offset:], bh)
+	offset += s.header.len()
+
+	binary.BigEndian.PutUint16(b[offset:offset+2], s.Value)
+
+	return b, nil
+}
+
+// Len returns length for signal block.
+func (s *Signal) Len() int {
+	return s.header.len() + 2
+}
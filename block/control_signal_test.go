@@ -0,0 +1,31 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestControlSignalMarshalBinary(t *testing.T) {
+	s := NewSignal()
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	expected := []byte{0x05, 0x03, 0x00, 0x04, 0x00, 0x00, 0x01, 0x00}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestControlSignalUnmarshalBinary(t *testing.T) {
+	b := []byte{0x05, 0x03, 0x00, 0x04, 0x00, 0x00, 0x01, 0x00}
+	s := &Signal{}
+	s.HasQualifier = true
+	if err := s.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	}
+	if s.Value != SignalFlashOnce {
+		t.Errorf("expected %d; got %d", SignalFlashOnce, s.Value)
+	}
+}
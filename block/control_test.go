@@ -0,0 +1,48 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestControlMarshalBinary(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        *Control
+		expected []byte
+	}{
+		{
+			name:     "start",
+			c:        NewStart(),
+			expected: []byte{0x05, 0x01, 0x00, 0x02, 0x00, 0x00},
+		},
+		{
+			name:     "stop",
+			c:        NewStop(),
+			expected: []byte{0x05, 0x02, 0x00, 0x02, 0x00, 0x00},
+		},
+		{
+			name:     "factory reset",
+			c:        NewFactoryReset(),
+			expected: []byte{0x05, 0x05, 0x00, 0x02, 0x00, 0x00},
+		},
+		{
+			name:     "reset to factory",
+			c:        NewResetToFactory(ResetCommunication),
+			expected: []byte{0x05, 0x06, 0x00, 0x02, 0x00, 0x04},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.c.MarshalBinary()
+			if err != nil {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(b, tt.expected); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -24,6 +24,9 @@ func (d *DeviceInitiative) UnmarshalBinary(b []byte) error {
 	if err := d.header.unmarshalBinary(b); err != nil {
 		return err
 	}
+	if err := d.header.checkLength(2); err != nil {
+		return err
+	}
 
 	i := d.header.len()
 	d.Value = binary.BigEndian.Uint16(b[i : i+2])
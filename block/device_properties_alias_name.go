@@ -0,0 +1,85 @@
+package block
+
+import (
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// AliasName is an alias name block. The alias name is built from the port
+// and chassis name of the neighbor the device is connected to.
+type AliasName struct {
+	header
+	AliasName string
+}
+
+var _ Block = &AliasName{}
+
+// NewAliasNameWithInfo returns new AliasName block.
+func NewAliasNameWithInfo(info uint16, name string) *AliasName {
+	return &AliasName{
+		header: header{
+			Option:       option.Properties,
+			Suboption:    suboption.AliasName,
+			Length:       uint16(len(name) + 2),
+			HasInfo:      true,
+			Info:         info,
+			HasQualifier: false,
+		},
+		AliasName: name,
+	}
+}
+
+// NewAliasNameFilter returns a new block for identify requests.
+func NewAliasNameFilter(name string) *AliasName {
+	return &AliasName{
+		header: header{
+			Option:    option.Properties,
+			Suboption: suboption.AliasName,
+			Length:    uint16(len(name)),
+		},
+		AliasName: name,
+	}
+}
+
+// NewAliasName returns a new block.
+func NewAliasName(hasInfo bool) *AliasName {
+	return &AliasName{
+		header: header{
+			HasInfo: hasInfo,
+		},
+	}
+}
+
+// UnmarshalBinary turns bytes into struct.
+func (a *AliasName) UnmarshalBinary(b []byte) error {
+	if err := a.header.unmarshalBinary(b); err != nil {
+		return err
+	}
+
+	a.AliasName = string(a.header.payload(b))
+
+	return nil
+}
+
+// MarshalBinary converts struct into byte slice.
+func (a *AliasName) MarshalBinary() ([]byte, error) {
+	b := make([]byte, a.Len())
+
+	bh, err := a.header.marshalBinary()
+	if err != nil {
+		return b, err
+	}
+	offset := 0
+
+	copy(b[offset:], bh)
+	offset += a.header.len()
+
+	copy(b[offset:], a.AliasName)
+
+	return b, nil
+}
+
+// Len returns length for alias name block.
+func (a *AliasName) Len() int {
+	return a.header.len() + len(a.AliasName)
+}
@@ -0,0 +1,42 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAliasNameUnmarshalBinary(t *testing.T) {
+	b := []byte{
+		0x02, 0x06, 0x00, 0x11, 0x00, 0x00, 0x70, 0x6f,
+		0x72, 0x74, 0x2d, 0x30, 0x30, 0x31, 0x2e, 0x73,
+		0x77, 0x69, 0x74, 0x63, 0x68,
+	}
+
+	a := &AliasName{}
+	a.HasInfo = true
+
+	if err := a.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	}
+	if a.AliasName != "port-001.switch" {
+		t.Errorf("expected %s; got %s", "port-001.switch", a.AliasName)
+	}
+}
+
+func TestAliasNameFilterMarshalBinary(t *testing.T) {
+	a := NewAliasNameFilter("port-001.switch")
+
+	b, err := a.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	expected := []byte{
+		0x02, 0x06, 0x00, 0x0f, 0x70, 0x6f, 0x72, 0x74,
+		0x2d, 0x30, 0x30, 0x31, 0x2e, 0x73, 0x77, 0x69,
+		0x74, 0x63, 0x68,
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -1,6 +1,11 @@
 package block
 
-import "encoding/binary"
+import (
+	"encoding/binary"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
 
 // DeviceID is a device id block.
 type DeviceID struct {
@@ -11,11 +16,52 @@ type DeviceID struct {
 
 var _ Block = &DeviceID{}
 
+// NewDeviceIDWithInfo returns a new block.
+func NewDeviceIDWithInfo(info uint16, vendorID, deviceID uint16) *DeviceID {
+	return &DeviceID{
+		header: header{
+			Option:       option.Properties,
+			Suboption:    suboption.DeviceID,
+			Length:       6,
+			HasInfo:      true,
+			Info:         info,
+			HasQualifier: false,
+		},
+		VendorID: vendorID,
+		DeviceID: deviceID,
+	}
+}
+
+// NewDeviceIDFilter returns a new block for identify requests.
+func NewDeviceIDFilter(vendorID, deviceID uint16) *DeviceID {
+	return &DeviceID{
+		header: header{
+			Option:    option.Properties,
+			Suboption: suboption.DeviceID,
+			Length:    4,
+		},
+		VendorID: vendorID,
+		DeviceID: deviceID,
+	}
+}
+
+// NewDeviceID returns a new block.
+func NewDeviceID(hasInfo bool) *DeviceID {
+	return &DeviceID{
+		header: header{
+			HasInfo: hasInfo,
+		},
+	}
+}
+
 // UnmarshalBinary turns bytes into struct.
 func (d *DeviceID) UnmarshalBinary(b []byte) error {
 	if err := d.header.unmarshalBinary(b); err != nil {
 		return err
 	}
+	if err := d.header.checkLength(4); err != nil {
+		return err
+	}
 
 	i := d.header.len()
 	d.VendorID = binary.BigEndian.Uint16(b[i : i+2])
@@ -1,5 +1,10 @@
 package block
 
+import (
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
 // DeviceInstance is a device instance block.
 type DeviceInstance struct {
 	header
@@ -9,6 +14,35 @@ type DeviceInstance struct {
 
 var _ Block = &DeviceInstance{}
 
+// NewDeviceInstanceWithInfo returns a new block.
+func NewDeviceInstanceWithInfo(info uint16, high, low uint8) *DeviceInstance {
+	return &DeviceInstance{
+		header: header{
+			Option:       option.Properties,
+			Suboption:    suboption.DeviceInstance,
+			Length:       4,
+			HasInfo:      true,
+			Info:         info,
+			HasQualifier: false,
+		},
+		DeviceInstanceHigh: high,
+		DeviceInstanceLow:  low,
+	}
+}
+
+// NewDeviceInstanceFilter returns a new block for identify requests.
+func NewDeviceInstanceFilter(high, low uint8) *DeviceInstance {
+	return &DeviceInstance{
+		header: header{
+			Option:    option.Properties,
+			Suboption: suboption.DeviceInstance,
+			Length:    2,
+		},
+		DeviceInstanceHigh: high,
+		DeviceInstanceLow:  low,
+	}
+}
+
 // NewDeviceInstance returns a new block.
 func NewDeviceInstance(hasInfo bool) *DeviceInstance {
 	return &DeviceInstance{
@@ -23,6 +57,9 @@ func (d *DeviceInstance) UnmarshalBinary(b []byte) error {
 	if err := d.header.unmarshalBinary(b); err != nil {
 		return err
 	}
+	if err := d.header.checkLength(2); err != nil {
+		return err
+	}
 
 	i := d.header.len()
 	d.DeviceInstanceHigh = b[i]
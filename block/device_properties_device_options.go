@@ -0,0 +1,99 @@
+package block
+
+import (
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// Pair is an option and suboption pair.
+type Pair struct {
+	Option    option.Option
+	Suboption suboption.Suboption
+}
+
+// DeviceOptions is a device options block. It lists all options and
+// suboptions a device supports.
+type DeviceOptions struct {
+	header
+	Options []Pair
+}
+
+var _ Block = &DeviceOptions{}
+
+// NewDeviceOptionsWithInfo returns a new block.
+func NewDeviceOptionsWithInfo(options []Pair, info uint16) *DeviceOptions {
+	return &DeviceOptions{
+		header: header{
+			Option:       option.Properties,
+			Suboption:    suboption.DeviceOptions,
+			Length:       uint16(2 + 2*len(options)),
+			HasInfo:      true,
+			Info:         info,
+			HasQualifier: false,
+		},
+		Options: options,
+	}
+}
+
+// Supports reports whether the device supports option and suboption.
+func (d *DeviceOptions) Supports(opt option.Option, subopt suboption.Suboption) bool {
+	if d == nil {
+		return false
+	}
+	for _, p := range d.Options {
+		if p.Option == opt && p.Suboption == subopt {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalBinary turns bytes into struct.
+func (d *DeviceOptions) UnmarshalBinary(b []byte) error {
+	if err := d.header.unmarshalBinary(b); err != nil {
+		return err
+	}
+
+	payload := d.header.payload(b)
+	if len(payload)%2 != 0 {
+		return d.header.lengthError()
+	}
+
+	d.Options = make([]Pair, len(payload)/2)
+	for i := range d.Options {
+		d.Options[i] = Pair{
+			Option:    option.Option(payload[2*i]),
+			Suboption: suboption.Suboption(payload[2*i+1]),
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary converts struct into byte slice.
+func (d *DeviceOptions) MarshalBinary() ([]byte, error) {
+	b := make([]byte, d.Len())
+
+	bh, err := d.header.marshalBinary()
+	if err != nil {
+		return b, err
+	}
+	offset := 0
+
+	copy(b[offset:], bh)
+	offset += d.header.len()
+
+	for _, p := range d.Options {
+		b[offset] = byte(p.Option)
+		offset++
+		b[offset] = byte(p.Suboption)
+		offset++
+	}
+
+	return b, nil
+}
+
+// Len returns length for device options block.
+func (d *DeviceOptions) Len() int {
+	return d.header.len() + 2*len(d.Options)
+}
@@ -0,0 +1,70 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+func TestDeviceOptionsUnmarshalBinary(t *testing.T) {
+	b := []byte{
+		0x02, 0x05, 0x00, 0x08, 0x00, 0x00, 0x02, 0x02,
+		0x01, 0x02, 0x05, 0x03,
+	}
+
+	d := &DeviceOptions{}
+	d.HasInfo = true
+
+	if err := d.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	}
+	expected := []Pair{
+		{Option: option.Properties, Suboption: suboption.NameOfStation},
+		{Option: option.IP, Suboption: suboption.IPParameter},
+		{Option: option.Control, Suboption: suboption.Signal},
+	}
+	if diff := cmp.Diff(d.Options, expected); diff != "" {
+		t.Error(diff)
+	}
+	if !d.Supports(option.IP, suboption.IPParameter) {
+		t.Error("expected ip parameter to be supported")
+	}
+	if d.Supports(option.IP, suboption.FullIPSuite) {
+		t.Error("expected full ip suite not to be supported")
+	}
+}
+
+func TestDeviceOptionsUnmarshalBinaryOddLength(t *testing.T) {
+	b := []byte{0x02, 0x05, 0x00, 0x03, 0x00, 0x00, 0x02}
+
+	d := &DeviceOptions{}
+	d.HasInfo = true
+
+	if err := d.UnmarshalBinary(b); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDeviceOptionsMarshalBinary(t *testing.T) {
+	d := NewDeviceOptionsWithInfo([]Pair{
+		{Option: option.Properties, Suboption: suboption.NameOfStation},
+	}, 0)
+
+	b, err := d.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	expected := []byte{0x02, 0x05, 0x00, 0x04, 0x00, 0x00, 0x02, 0x02}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestDeviceOptionsNil(t *testing.T) {
+	var d *DeviceOptions
+	if d.Supports(option.Properties, suboption.NameOfStation) {
+		t.Error("expected name of station not to be supported")
+	}
+}
@@ -0,0 +1,99 @@
+package block
+
+import (
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// Role is a bit field of device roles.
+type Role uint8
+
+// Known roles.
+const (
+	RoleIODevice      Role = 0x01
+	RoleIOController  Role = 0x02
+	RoleIOMultidevice Role = 0x04
+	RolePNSupervisor  Role = 0x08
+)
+
+// DeviceRole is a device role block.
+type DeviceRole struct {
+	header
+	Role Role
+}
+
+var _ Block = &DeviceRole{}
+
+// NewDeviceRoleWithInfo returns a new block.
+func NewDeviceRoleWithInfo(role Role, info uint16) *DeviceRole {
+	return &DeviceRole{
+		header: header{
+			Option:       option.Properties,
+			Suboption:    suboption.DeviceRole,
+			Length:       4,
+			HasInfo:      true,
+			Info:         info,
+			HasQualifier: false,
+		},
+		Role: role,
+	}
+}
+
+// IsDevice reports whether the device is an io device.
+func (d *DeviceRole) IsDevice() bool {
+	return d != nil && d.Role&RoleIODevice != 0
+}
+
+// IsController reports whether the device is an io controller.
+func (d *DeviceRole) IsController() bool {
+	return d != nil && d.Role&RoleIOController != 0
+}
+
+// IsMultidevice reports whether the device is an io multidevice.
+func (d *DeviceRole) IsMultidevice() bool {
+	return d != nil && d.Role&RoleIOMultidevice != 0
+}
+
+// IsSupervisor reports whether the device is a pn supervisor.
+func (d *DeviceRole) IsSupervisor() bool {
+	return d != nil && d.Role&RolePNSupervisor != 0
+}
+
+// UnmarshalBinary turns bytes into struct.
+func (d *DeviceRole) UnmarshalBinary(b []byte) error {
+	if err := d.header.unmarshalBinary(b); err != nil {
+		return err
+	}
+	if err := d.header.checkLength(2); err != nil {
+		return err
+	}
+
+	i := d.header.len()
+	d.Role = Role(b[i])
+
+	return nil
+}
+
+// MarshalBinary converts struct into byte slice.
+func (d *DeviceRole) MarshalBinary() ([]byte, error) {
+	b := make([]byte, d.Len())
+
+	bh, err := d.header.marshalBinary()
+	if err != nil {
+		return b, err
+	}
+	offset := 0
+
+	copy(b[offset:], bh)
+	offset += d.header.len()
+
+	// role is followed by a reserved byte
+	b[offset] = byte(d.Role)
+
+	return b, nil
+}
+
+// Len returns length for device role block.
+func (d *DeviceRole) Len() int {
+	return d.header.len() + 1 + 1
+}
@@ -0,0 +1,50 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeviceRoleUnmarshalBinary(t *testing.T) {
+	b := []byte{0x02, 0x04, 0x00, 0x04, 0x00, 0x00, 0x03, 0x00}
+
+	d := &DeviceRole{}
+	d.HasInfo = true
+
+	if err := d.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	}
+	if d.Role != RoleIODevice|RoleIOController {
+		t.Errorf("expected %d; got %d", RoleIODevice|RoleIOController, d.Role)
+	}
+	if !d.IsDevice() {
+		t.Error("expected io device")
+	}
+	if !d.IsController() {
+		t.Error("expected io controller")
+	}
+	if d.IsMultidevice() || d.IsSupervisor() {
+		t.Error("expected no multidevice and no supervisor")
+	}
+}
+
+func TestDeviceRoleMarshalBinary(t *testing.T) {
+	d := NewDeviceRoleWithInfo(RolePNSupervisor, 0)
+
+	b, err := d.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	expected := []byte{0x02, 0x04, 0x00, 0x04, 0x00, 0x00, 0x08, 0x00}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestDeviceRoleNil(t *testing.T) {
+	var d *DeviceRole
+	if d.IsController() {
+		t.Error("expected no io controller")
+	}
+}
@@ -1,5 +1,10 @@
 package block
 
+import (
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
 // ManufacturerSpecific is a manufacturer specific block.
 type ManufacturerSpecific struct {
 	header
@@ -8,6 +13,21 @@ type ManufacturerSpecific struct {
 
 var _ Block = &ManufacturerSpecific{}
 
+// NewManufacturerSpecificWithInfo returns a new block.
+func NewManufacturerSpecificWithInfo(info uint16, value string) *ManufacturerSpecific {
+	return &ManufacturerSpecific{
+		header: header{
+			Option:       option.Properties,
+			Suboption:    suboption.ManufacturerSpecific,
+			Length:       uint16(len(value) + 2),
+			HasInfo:      true,
+			Info:         info,
+			HasQualifier: false,
+		},
+		DeviceVendorValue: value,
+	}
+}
+
 // NewManufacturerSpecific returns a new block.
 func NewManufacturerSpecific(hasInfo bool) *ManufacturerSpecific {
 	return &ManufacturerSpecific{
@@ -23,8 +43,7 @@ func (m *ManufacturerSpecific) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	i := m.header.len()
-	m.DeviceVendorValue = string(b[i : i+int(m.header.Length)-2])
+	m.DeviceVendorValue = string(m.header.payload(b))
 
 	return nil
 }
@@ -50,4 +69,4 @@ func (m *ManufacturerSpecific) MarshalBinary() ([]byte, error) {
 // Len returns length for name of station block.
 func (m *ManufacturerSpecific) Len() int {
 	return m.header.len() + len(m.DeviceVendorValue)
-}
\ No newline at end of file
+}
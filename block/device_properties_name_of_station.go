@@ -28,6 +28,33 @@ func NewNameOfStationWithInfo(info uint16, name string) *NameOfStation {
 	}
 }
 
+// NewNameOfStationQualifier returns a new block for set requests.
+func NewNameOfStationQualifier(qualifier uint16, name string) *NameOfStation {
+	return &NameOfStation{
+		header: header{
+			Option:       option.Properties,
+			Suboption:    suboption.NameOfStation,
+			Length:       uint16(len(name) + 2),
+			HasInfo:      false,
+			HasQualifier: true,
+			Qualifier:    qualifier,
+		},
+		NameOfStation: name,
+	}
+}
+
+// NewNameOfStationFilter returns a new block for identify requests.
+func NewNameOfStationFilter(name string) *NameOfStation {
+	return &NameOfStation{
+		header: header{
+			Option:    option.Properties,
+			Suboption: suboption.NameOfStation,
+			Length:    uint16(len(name)),
+		},
+		NameOfStation: name,
+	}
+}
+
 // NewNameOfStation returns a new block.
 func NewNameOfStation(hasInfo bool) *NameOfStation {
 	return &NameOfStation{
@@ -43,8 +70,7 @@ func (n *NameOfStation) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	i := n.header.len()
-	n.NameOfStation = string(b[i : int(i)+int(n.header.Length)-2])
+	n.NameOfStation = string(n.header.payload(b))
 
 	return nil
 }
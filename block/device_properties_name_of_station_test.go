@@ -52,3 +52,26 @@ func TestDevicePropertiesNameOfStationLen(t *testing.T) {
 		t.Errorf("expected %d; got %d", 11, nos.Len())
 	}
 }
+
+func TestDevicePropertiesNameOfStationFilter(t *testing.T) {
+	nos := NewNameOfStationFilter("zeiss")
+	b, err := nos.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	expected := []byte{
+		0x02, 0x02, 0x00, 0x05, 0x7a, 0x65, 0x69, 0x73,
+		0x73,
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+
+	var decoded NameOfStation
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	}
+	if decoded.NameOfStation != "zeiss" {
+		t.Errorf("expected %s; got %s", "zeiss", decoded.NameOfStation)
+	}
+}
@@ -0,0 +1,98 @@
+package block
+
+import (
+	"encoding/binary"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// OEMDeviceID is an oem device id block.
+type OEMDeviceID struct {
+	header
+	VendorID uint16
+	DeviceID uint16
+}
+
+var _ Block = &OEMDeviceID{}
+
+// NewOEMDeviceIDWithInfo returns new OEMDeviceID block.
+func NewOEMDeviceIDWithInfo(info uint16, vendorID, deviceID uint16) *OEMDeviceID {
+	return &OEMDeviceID{
+		header: header{
+			Option:       option.Properties,
+			Suboption:    suboption.OEMDeviceID,
+			Length:       6,
+			HasInfo:      true,
+			Info:         info,
+			HasQualifier: false,
+		},
+		VendorID: vendorID,
+		DeviceID: deviceID,
+	}
+}
+
+// NewOEMDeviceIDFilter returns a new block for identify requests.
+func NewOEMDeviceIDFilter(vendorID, deviceID uint16) *OEMDeviceID {
+	return &OEMDeviceID{
+		header: header{
+			Option:    option.Properties,
+			Suboption: suboption.OEMDeviceID,
+			Length:    4,
+		},
+		VendorID: vendorID,
+		DeviceID: deviceID,
+	}
+}
+
+// NewOEMDeviceID returns a new block.
+func NewOEMDeviceID(hasInfo bool) *OEMDeviceID {
+	return &OEMDeviceID{
+		header: header{
+			HasInfo: hasInfo,
+		},
+	}
+}
+
+// UnmarshalBinary turns bytes into struct.
+func (d *OEMDeviceID) UnmarshalBinary(b []byte) error {
+	if err := d.header.unmarshalBinary(b); err != nil {
+		return err
+	}
+	if err := d.header.checkLength(4); err != nil {
+		return err
+	}
+
+	i := d.header.len()
+	d.VendorID = binary.BigEndian.Uint16(b[i : i+2])
+	i += 2
+	d.DeviceID = binary.BigEndian.Uint16(b[i : i+2])
+
+	return nil
+}
+
+// MarshalBinary converts struct into byte slice.
+func (d *OEMDeviceID) MarshalBinary() ([]byte, error) {
+	b := make([]byte, d.Len())
+
+	bh, err := d.header.marshalBinary()
+	if err != nil {
+		return b, err
+	}
+	offset := 0
+
+	copy(b[offset:], bh)
+	offset += d.header.len()
+
+	binary.BigEndian.PutUint16(b[offset:offset+2], d.VendorID)
+	offset += 2
+
+	binary.BigEndian.PutUint16(b[offset:offset+2], d.DeviceID)
+
+	return b, nil
+}
+
+// Len returns length for oem device id block.
+func (d *OEMDeviceID) Len() int {
+	return d.header.len() + 2 + 2
+}
@@ -0,0 +1,55 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOEMDeviceIDUnmarshalBinary(t *testing.T) {
+	b := []byte{0x02, 0x08, 0x00, 0x06, 0x00, 0x00, 0x01, 0x1e, 0x0a, 0x01}
+
+	d := &OEMDeviceID{}
+	d.HasInfo = true
+
+	if err := d.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	}
+	if d.VendorID != 0x011e {
+		t.Errorf("expected %d; got %d", 0x011e, d.VendorID)
+	}
+	if d.DeviceID != 0x0a01 {
+		t.Errorf("expected %d; got %d", 0x0a01, d.DeviceID)
+	}
+}
+
+func TestOEMDeviceIDMarshalBinary(t *testing.T) {
+	tests := []struct {
+		name     string
+		block    *OEMDeviceID
+		expected []byte
+	}{
+		{
+			name:     "with info",
+			block:    NewOEMDeviceIDWithInfo(0, 0x011e, 0x0a01),
+			expected: []byte{0x02, 0x08, 0x00, 0x06, 0x00, 0x00, 0x01, 0x1e, 0x0a, 0x01},
+		},
+		{
+			name:     "filter",
+			block:    NewOEMDeviceIDFilter(0x011e, 0x0a01),
+			expected: []byte{0x02, 0x08, 0x00, 0x04, 0x01, 0x1e, 0x0a, 0x01},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.block.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(b, tt.expected); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
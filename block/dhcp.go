@@ -0,0 +1,148 @@
+package block
+
+import (
+	"net"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// Client identifier types of the dhcp client identifier block. The encoding
+// is defined in IEC 61158-6-10: the type byte is followed by the optional
+// client identifier. Without it the device builds the client identifier from
+// its name of station or its mac address.
+const (
+	// ClientIdentifierNameOfStation identifies the client by its name of
+	// station.
+	ClientIdentifierNameOfStation uint8 = 0x00
+	// ClientIdentifierMACAddress identifies the client by its mac address.
+	ClientIdentifierMACAddress uint8 = 0x01
+)
+
+// DHCP is a dhcp block. It is used for all suboptions of the dhcp option.
+// Data holds the dhcp parameter value.
+type DHCP struct {
+	header
+	Data []byte
+}
+
+var _ Block = &DHCP{}
+
+// NewDHCPWithInfo returns new DHCP block.
+func NewDHCPWithInfo(subopt suboption.Suboption, info uint16, data []byte) *DHCP {
+	return &DHCP{
+		header: header{
+			Option:       option.DHCP,
+			Suboption:    subopt,
+			Length:       uint16(len(data) + 2),
+			HasInfo:      true,
+			Info:         info,
+			HasQualifier: false,
+		},
+		Data: data,
+	}
+}
+
+// NewDHCPQualifier returns a new block for set requests.
+func NewDHCPQualifier(subopt suboption.Suboption, qualifier uint16, data []byte) *DHCP {
+	return &DHCP{
+		header: header{
+			Option:       option.DHCP,
+			Suboption:    subopt,
+			Length:       uint16(len(data) + 2),
+			HasInfo:      false,
+			HasQualifier: true,
+			Qualifier:    qualifier,
+		},
+		Data: data,
+	}
+}
+
+// NewDHCPClientIdentifierQualifier returns a new client identifier block for
+// set requests. Setting the client identifier switches the device to dhcp.
+// Without id the device uses its name of station or mac address depending
+// on typ.
+func NewDHCPClientIdentifierQualifier(qualifier uint16, typ uint8, id []byte) *DHCP {
+	data := make([]byte, 1+len(id))
+	data[0] = typ
+	copy(data[1:], id)
+	return NewDHCPQualifier(suboption.DHCPClientIdentifier, qualifier, data)
+}
+
+// NewDHCP returns a new block.
+func NewDHCP(hasInfo bool) *DHCP {
+	return &DHCP{
+		header: header{
+			HasInfo: hasInfo,
+		},
+	}
+}
+
+// Text returns the value of text suboptions like host name, class
+// identifier or fully qualified domain name.
+func (d *DHCP) Text() string {
+	return string(d.Data)
+}
+
+// ClientIdentifier returns type and identifier of a client identifier
+// block. It reports false if the block holds no client identifier.
+func (d *DHCP) ClientIdentifier() (uint8, []byte, bool) {
+	if d.Suboption != suboption.DHCPClientIdentifier || len(d.Data) == 0 {
+		return 0, nil, false
+	}
+	return d.Data[0], d.Data[1:], true
+}
+
+// ServerIdentifier returns the ip address of a server identifier block or
+// nil.
+func (d *DHCP) ServerIdentifier() net.IP {
+	if d.Suboption != suboption.ServerIdentifier || len(d.Data) != 4 {
+		return nil
+	}
+	return net.IP(d.Data)
+}
+
+// ParameterRequestList returns the requested dhcp options of a parameter
+// request list block or nil.
+func (d *DHCP) ParameterRequestList() []uint8 {
+	if d.Suboption != suboption.ParameterRequestList {
+		return nil
+	}
+	return d.Data
+}
+
+// UnmarshalBinary turns bytes into struct.
+func (d *DHCP) UnmarshalBinary(b []byte) error {
+	if err := d.header.unmarshalBinary(b); err != nil {
+		return err
+	}
+
+	payload := d.header.payload(b)
+	d.Data = make([]byte, len(payload))
+	copy(d.Data, payload)
+
+	return nil
+}
+
+// MarshalBinary converts struct into byte slice.
+func (d *DHCP) MarshalBinary() ([]byte, error) {
+	b := make([]byte, d.Len())
+
+	bh, err := d.header.marshalBinary()
+	if err != nil {
+		return b, err
+	}
+	offset := 0
+
+	copy(b[offset:], bh)
+	offset += d.header.len()
+
+	copy(b[offset:], d.Data)
+
+	return b, nil
+}
+
+// Len returns length for dhcp block.
+func (d *DHCP) Len() int {
+	return d.header.len() + len(d.Data)
+}
@@ -0,0 +1,99 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zemirco/dcp/suboption"
+)
+
+func TestDHCPUnmarshalBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		test func(t *testing.T, d *DHCP)
+	}{
+		{
+			name: "host name",
+			b:    []byte{0x03, 0x0c, 0x00, 0x07, 0x00, 0x00, 0x7a, 0x65, 0x69, 0x73, 0x73},
+			test: func(t *testing.T, d *DHCP) {
+				if d.Text() != "zeiss" {
+					t.Errorf("expected %s; got %s", "zeiss", d.Text())
+				}
+			},
+		},
+		{
+			name: "server identifier",
+			b:    []byte{0x03, 0x36, 0x00, 0x06, 0x00, 0x00, 0xac, 0x13, 0x68, 0x01},
+			test: func(t *testing.T, d *DHCP) {
+				if d.ServerIdentifier().String() != "172.19.104.1" {
+					t.Errorf("expected %s; got %s", "172.19.104.1", d.ServerIdentifier())
+				}
+			},
+		},
+		{
+			name: "parameter request list",
+			b:    []byte{0x03, 0x37, 0x00, 0x05, 0x00, 0x00, 0x01, 0x03, 0x06},
+			test: func(t *testing.T, d *DHCP) {
+				if diff := cmp.Diff(d.ParameterRequestList(), []uint8{0x01, 0x03, 0x06}); diff != "" {
+					t.Error(diff)
+				}
+			},
+		},
+		{
+			name: "client identifier",
+			b:    []byte{0x03, 0x3d, 0x00, 0x08, 0x00, 0x00, 0x00, 0x7a, 0x65, 0x69, 0x73, 0x73},
+			test: func(t *testing.T, d *DHCP) {
+				typ, id, ok := d.ClientIdentifier()
+				if !ok {
+					t.Fatal("expected client identifier")
+				}
+				if typ != ClientIdentifierNameOfStation {
+					t.Errorf("expected %d; got %d", ClientIdentifierNameOfStation, typ)
+				}
+				if string(id) != "zeiss" {
+					t.Errorf("expected %s; got %s", "zeiss", id)
+				}
+				if d.ServerIdentifier() != nil {
+					t.Errorf("expected no server identifier; got %s", d.ServerIdentifier())
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDHCP(true)
+			if err := d.UnmarshalBinary(tt.b); err != nil {
+				t.Fatal(err)
+			}
+			tt.test(t, d)
+		})
+	}
+}
+
+func TestDHCPClientIdentifierMarshalBinary(t *testing.T) {
+	d := NewDHCPClientIdentifierQualifier(QualifierPermanent, ClientIdentifierNameOfStation, nil)
+
+	b, err := d.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	expected := []byte{0x03, 0x3d, 0x00, 0x03, 0x00, 0x01, 0x00}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestDHCPMarshalBinary(t *testing.T) {
+	d := NewDHCPWithInfo(suboption.HostName, 0, []byte("zeiss"))
+
+	b, err := d.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	expected := []byte{0x03, 0x0c, 0x00, 0x07, 0x00, 0x00, 0x7a, 0x65, 0x69, 0x73, 0x73}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,79 @@
+package block
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// ErrTruncated is returned when a byte slice is shorter than the block it
+// should contain.
+var ErrTruncated = errors.New("block: truncated")
+
+// BlockError is the error code of a control response block.
+type BlockError uint8
+
+// Known block errors.
+const (
+	NoError               BlockError = 0x00
+	OptionNotSupported    BlockError = 0x01
+	SuboptionNotSupported BlockError = 0x02
+	SuboptionNotSet       BlockError = 0x03
+	ResourceError         BlockError = 0x04
+	SetNotPossible        BlockError = 0x05
+	InOperation           BlockError = 0x06
+)
+
+// Errors for the known block errors. Use errors.Is to check a ResponseError
+// against them.
+var (
+	ErrOptionNotSupported    = errors.New("block: option not supported")
+	ErrSuboptionNotSupported = errors.New("block: suboption not supported or no data set available")
+	ErrSuboptionNotSet       = errors.New("block: suboption not set")
+	ErrResource              = errors.New("block: resource error")
+	ErrSetNotPossible        = errors.New("block: set not possible by local reasons")
+	ErrInOperation           = errors.New("block: in operation, set not possible")
+)
+
+var blockErrors = map[BlockError]error{
+	OptionNotSupported:    ErrOptionNotSupported,
+	SuboptionNotSupported: ErrSuboptionNotSupported,
+	SuboptionNotSet:       ErrSuboptionNotSet,
+	ResourceError:         ErrResource,
+	SetNotPossible:        ErrSetNotPossible,
+	InOperation:           ErrInOperation,
+}
+
+// ResponseError is returned when a device answers a request with an error
+// code in a control response block.
+type ResponseError struct {
+	Option    option.Option
+	Suboption suboption.Suboption
+	Code      BlockError
+}
+
+func (e *ResponseError) Error() string {
+	if err, ok := blockErrors[e.Code]; ok {
+		return fmt.Sprintf("%v for option %#02x suboption %#02x", err, e.Option, e.Suboption)
+	}
+	return fmt.Sprintf("block: unknown error %#02x for option %#02x suboption %#02x", uint8(e.Code), e.Option, e.Suboption)
+}
+
+// Unwrap returns the error for the block error code.
+func (e *ResponseError) Unwrap() error {
+	return blockErrors[e.Code]
+}
+
+// LengthError is returned when the length field of a block does not match
+// its block type.
+type LengthError struct {
+	Option    option.Option
+	Suboption suboption.Suboption
+	Length    uint16
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("block: invalid length %d for option %#02x suboption %#02x", e.Length, e.Option, e.Suboption)
+}
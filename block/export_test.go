@@ -0,0 +1,17 @@
+package block
+
+import (
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// unregister removes a block type so tests can clean up the registry.
+func unregister(opt option.Option, subopt suboption.Suboption) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	delete(factories, key{option: opt, suboption: subopt})
+}
+
+// Unregister exports unregister for tests of package block_test.
+var Unregister = unregister
@@ -0,0 +1,87 @@
+package block
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+func FuzzBlockUnmarshalBinary(f *testing.F) {
+	seeds := []struct {
+		hasInfo      bool
+		hasQualifier bool
+		b            []byte
+	}{
+		{false, false, []byte{0xff, 0xff, 0x00, 0x00}},
+		{true, false, []byte{0x02, 0x01, 0x00, 0x09, 0x00, 0x00, 0x45, 0x54, 0x32, 0x30, 0x30, 0x53, 0x50}},
+		{true, false, []byte{0x02, 0x02, 0x00, 0x07, 0x00, 0x00, 0x7a, 0x65, 0x69, 0x73, 0x73}},
+		{false, true, []byte{0x02, 0x02, 0x00, 0x07, 0x00, 0x01, 0x7a, 0x65, 0x69, 0x73, 0x73}},
+		{false, false, []byte{0x02, 0x02, 0x00, 0x05, 0x7a, 0x65, 0x69, 0x73, 0x73}},
+		{true, false, []byte{0x02, 0x03, 0x00, 0x06, 0x00, 0x00, 0x00, 0x2a, 0x01, 0x0a}},
+		{true, false, []byte{0x02, 0x04, 0x00, 0x04, 0x00, 0x00, 0x02, 0x00}},
+		{true, false, []byte{0x02, 0x05, 0x00, 0x06, 0x00, 0x00, 0x02, 0x07, 0x01, 0x02}},
+		{true, false, []byte{0x02, 0x04, 0x00, 0x04, 0x00, 0x00, 0x05, 0x00}},
+		{true, false, []byte{0x02, 0x05, 0x00, 0x0a, 0x00, 0x00, 0x01, 0x02, 0x02, 0x02, 0x05, 0x03, 0x05, 0x04}},
+		{true, false, []byte{0x02, 0x06, 0x00, 0x05, 0x00, 0x00, 0x70, 0x2e, 0x73}},
+		{true, false, []byte{0x02, 0x07, 0x00, 0x04, 0x00, 0x00, 0x00, 0x64}},
+		{true, false, []byte{0x02, 0x08, 0x00, 0x06, 0x00, 0x00, 0x01, 0x1e, 0x0a, 0x01}},
+		{true, false, []byte{0x01, 0x01, 0x00, 0x08, 0x00, 0x00, 0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}},
+		{true, false, []byte{0x01, 0x02, 0x00, 0x0e, 0x00, 0x01, 0xac, 0x13, 0x68, 0x05, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{false, true, []byte{0x01, 0x02, 0x00, 0x0e, 0x00, 0x01, 0xac, 0x13, 0x68, 0x05, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{true, false, []byte{0x01, 0x03, 0x00, 0x1e, 0x00, 0x01, 0xac, 0x13, 0x68, 0x05, 0xff, 0xff, 0x00, 0x00, 0xac, 0x13, 0x00, 0x01, 0x08, 0x08, 0x08, 0x08, 0x01, 0x01, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{false, true, []byte{0x01, 0x03, 0x00, 0x1e, 0x00, 0x01, 0xac, 0x13, 0x68, 0x05, 0xff, 0xff, 0x00, 0x00, 0xac, 0x13, 0x00, 0x01, 0x08, 0x08, 0x08, 0x08, 0x01, 0x01, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{true, false, []byte{0x03, 0x0c, 0x00, 0x07, 0x00, 0x00, 0x7a, 0x65, 0x69, 0x73, 0x73}},
+		{false, true, []byte{0x03, 0x3d, 0x00, 0x03, 0x00, 0x01, 0x00}},
+		{false, true, []byte{0x05, 0x01, 0x00, 0x02, 0x00, 0x00}},
+		{false, true, []byte{0x05, 0x02, 0x00, 0x02, 0x00, 0x00}},
+		{false, true, []byte{0x05, 0x03, 0x00, 0x04, 0x00, 0x00, 0x01, 0x00}},
+		{false, true, []byte{0x05, 0x05, 0x00, 0x02, 0x00, 0x00}},
+		{false, true, []byte{0x05, 0x06, 0x00, 0x02, 0x00, 0x04}},
+		{false, false, []byte{0x05, 0x04, 0x00, 0x03, 0x01, 0x02, 0x00}},
+		{true, false, []byte{0x06, 0x01, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01}},
+	}
+	for _, s := range seeds {
+		f.Add(s.hasInfo, s.hasQualifier, s.b)
+	}
+
+	f.Fuzz(func(t *testing.T, hasInfo, hasQualifier bool, b []byte) {
+		if len(b) < 2 {
+			return
+		}
+		opt := option.Option(b[0])
+		subopt := suboption.Suboption(b[1])
+
+		newBlock := func() Block {
+			if blk, ok := New(opt, subopt, hasInfo, hasQualifier); ok {
+				return blk
+			}
+			return NewRaw(hasInfo, hasQualifier)
+		}
+
+		first := newBlock()
+		if err := first.UnmarshalBinary(b); err != nil {
+			return
+		}
+		encoded, err := first.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(encoded) != first.Len() {
+			t.Fatalf("expected %d; got %d", first.Len(), len(encoded))
+		}
+
+		second := newBlock()
+		if err := second.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("decoding encoded block: %v", err)
+		}
+		reencoded, err := second.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, reencoded) {
+			t.Errorf("encoding not stable:\n%x\n%x", encoded, reencoded)
+		}
+	})
+}
@@ -7,6 +7,12 @@ import (
 	"github.com/zemirco/dcp/suboption"
 )
 
+// Block qualifiers for set requests.
+const (
+	QualifierTemporary uint16 = 0x0000
+	QualifierPermanent uint16 = 0x0001
+)
+
 // Header is block header.
 type header struct {
 	Option    option.Option
@@ -19,6 +25,11 @@ type header struct {
 	Qualifier    uint16
 }
 
+// Type returns option and suboption of the block.
+func (h *header) Type() (option.Option, suboption.Suboption) {
+	return h.Option, h.Suboption
+}
+
 // MarshalBinary converts struct into byte slice.
 func (h *header) marshalBinary() ([]byte, error) {
 	length := 4
@@ -57,6 +68,10 @@ func (h *header) marshalBinary() ([]byte, error) {
 
 // UnmarshalBinary turns bytes into struct.
 func (h *header) unmarshalBinary(b []byte) error {
+	if len(b) < h.len() {
+		return ErrTruncated
+	}
+
 	offset := 0
 
 	h.Option = option.Option(b[offset])
@@ -78,9 +93,38 @@ func (h *header) unmarshalBinary(b []byte) error {
 		offset += 2
 	}
 
+	if int(h.Length) < h.len()-4 {
+		return h.lengthError()
+	}
+	if len(b) < 4+int(h.Length) {
+		return ErrTruncated
+	}
+
 	return nil
 }
 
+// payload returns the block data following the header.
+// It must only be called after a successful unmarshalBinary.
+func (h *header) payload(b []byte) []byte {
+	return b[h.len() : 4+int(h.Length)]
+}
+
+// checkLength verifies that the block carries exactly n bytes of data.
+func (h *header) checkLength(n int) error {
+	if int(h.Length) != h.len()-4+n {
+		return h.lengthError()
+	}
+	return nil
+}
+
+func (h *header) lengthError() error {
+	return &LengthError{
+		Option:    h.Option,
+		Suboption: h.Suboption,
+		Length:    h.Length,
+	}
+}
+
 // Len returns block header length.
 func (h *header) len() int {
 	length := 4
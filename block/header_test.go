@@ -0,0 +1,30 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+func TestHeaderType(t *testing.T) {
+	tests := []struct {
+		block     Typer
+		option    option.Option
+		suboption suboption.Suboption
+	}{
+		{NewAll(), option.All, suboption.All},
+		{NewNameOfStationFilter("zeiss"), option.Properties, suboption.NameOfStation},
+		{NewIPParameterQualifier(), option.IP, suboption.IPParameter},
+		{NewResetToFactory(ResetCommunication), option.Control, suboption.ResetToFactory},
+		{NewSignal(), option.Control, suboption.Signal},
+		{NewDHCPClientIdentifierQualifier(QualifierPermanent, 0x00, nil), option.DHCP, suboption.DHCPClientIdentifier},
+	}
+
+	for _, tt := range tests {
+		opt, subopt := tt.block.Type()
+		if opt != tt.option || subopt != tt.suboption {
+			t.Errorf("expected %#02x %#02x; got %#02x %#02x", tt.option, tt.suboption, opt, subopt)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package block
+
+import (
+	"net"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// FullIPSuite is a full ip suite block. It carries the ip parameters and up
+// to four dns servers. Unused dns servers are 0.0.0.0.
+type FullIPSuite struct {
+	header
+	IPAddress       net.IP
+	Subnetmask      net.IP
+	StandardGateway net.IP
+	DNSServers      [4]net.IP
+}
+
+var _ Block = &FullIPSuite{}
+
+// NewFullIPSuiteWithInfo returns a new block.
+func NewFullIPSuiteWithInfo(ip, subnet, gateway net.IP, dns []net.IP, info uint16) *FullIPSuite {
+	f := &FullIPSuite{
+		header: header{
+			Option:       option.IP,
+			Suboption:    suboption.FullIPSuite,
+			Length:       30,
+			HasInfo:      true,
+			Info:         info,
+			HasQualifier: false,
+		},
+		IPAddress:       ip,
+		Subnetmask:      subnet,
+		StandardGateway: gateway,
+	}
+	copy(f.DNSServers[:], dns)
+	return f
+}
+
+// NewFullIPSuiteQualifier returns a new block.
+func NewFullIPSuiteQualifier() *FullIPSuite {
+	return &FullIPSuite{
+		header: header{
+			Option:       option.IP,
+			Suboption:    suboption.FullIPSuite,
+			Length:       30,
+			HasInfo:      false,
+			HasQualifier: true,
+		},
+	}
+}
+
+// UnmarshalBinary turns bytes into struct.
+func (f *FullIPSuite) UnmarshalBinary(b []byte) error {
+	if err := f.header.unmarshalBinary(b); err != nil {
+		return err
+	}
+	if err := f.header.checkLength(28); err != nil {
+		return err
+	}
+
+	offset := f.header.len()
+
+	f.IPAddress = net.IP(b[offset : offset+4])
+	offset += 4
+
+	f.Subnetmask = net.IP(b[offset : offset+4])
+	offset += 4
+
+	f.StandardGateway = net.IP(b[offset : offset+4])
+	offset += 4
+
+	for i := range f.DNSServers {
+		f.DNSServers[i] = net.IP(b[offset : offset+4])
+		offset += 4
+	}
+
+	return nil
+}
+
+// MarshalBinary converts struct into byte slice.
+func (f *FullIPSuite) MarshalBinary() ([]byte, error) {
+	b := make([]byte, f.Len())
+
+	bh, err := f.header.marshalBinary()
+	if err != nil {
+		return b, err
+	}
+	offset := 0
+
+	copy(b[offset:], bh)
+	offset += f.header.len()
+
+	putIP(b[offset:offset+4], f.IPAddress)
+	offset += 4
+
+	putIP(b[offset:offset+4], f.Subnetmask)
+	offset += 4
+
+	putIP(b[offset:offset+4], f.StandardGateway)
+	offset += 4
+
+	for _, dns := range f.DNSServers {
+		putIP(b[offset:offset+4], dns)
+		offset += 4
+	}
+
+	return b, nil
+}
+
+// Len returns length for full ip suite block.
+func (f *FullIPSuite) Len() int {
+	return f.header.len() + 4 + 4 + 4 + 4*4
+}
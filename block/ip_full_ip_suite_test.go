@@ -0,0 +1,68 @@
+package block
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIPFullIPSuiteUnmarshalBinary(t *testing.T) {
+	b := []byte{
+		0x01, 0x03, 0x00, 0x1e, 0x00, 0x01, 0xac, 0x13,
+		0x68, 0x05, 0xff, 0xff, 0x00, 0x00, 0xac, 0x13,
+		0x00, 0x01, 0x08, 0x08, 0x08, 0x08, 0x01, 0x01,
+		0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00,
+	}
+
+	f := &FullIPSuite{}
+	f.HasInfo = true
+
+	if err := f.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	}
+	if f.IPAddress.String() != "172.19.104.5" {
+		t.Errorf("expected %s; got %s", "172.19.104.5", f.IPAddress)
+	}
+	if f.StandardGateway.String() != "172.19.0.1" {
+		t.Errorf("expected %s; got %s", "172.19.0.1", f.StandardGateway)
+	}
+	if f.DNSServers[0].String() != "8.8.8.8" {
+		t.Errorf("expected %s; got %s", "8.8.8.8", f.DNSServers[0])
+	}
+	if f.DNSServers[1].String() != "1.1.1.1" {
+		t.Errorf("expected %s; got %s", "1.1.1.1", f.DNSServers[1])
+	}
+	if !f.DNSServers[3].IsUnspecified() {
+		t.Errorf("expected %s; got %s", "0.0.0.0", f.DNSServers[3])
+	}
+}
+
+func TestIPFullIPSuiteMarshalBinary(t *testing.T) {
+	f := NewFullIPSuiteWithInfo(
+		net.ParseIP("172.19.104.5"),
+		net.ParseIP("255.255.0.0"),
+		net.ParseIP("172.19.0.1"),
+		[]net.IP{net.ParseIP("8.8.8.8"), net.ParseIP("1.1.1.1")},
+		1,
+	)
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	expected := []byte{
+		0x01, 0x03, 0x00, 0x1e, 0x00, 0x01, 0xac, 0x13,
+		0x68, 0x05, 0xff, 0xff, 0x00, 0x00, 0xac, 0x13,
+		0x00, 0x01, 0x08, 0x08, 0x08, 0x08, 0x01, 0x01,
+		0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00,
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+	if f.Len() != 34 {
+		t.Errorf("expected %d; got %d", 34, f.Len())
+	}
+}
@@ -34,6 +34,20 @@ func NewIPParameterWithInfo(ip, subnet, gateway net.IP, info uint16) *IPParamete
 	}
 }
 
+// NewIPParameterFilter returns a new block for identify requests.
+func NewIPParameterFilter(ip, subnet, gateway net.IP) *IPParameter {
+	return &IPParameter{
+		header: header{
+			Option:    option.IP,
+			Suboption: suboption.IPParameter,
+			Length:    12,
+		},
+		IPAddress:       ip,
+		Subnetmask:      subnet,
+		StandardGateway: gateway,
+	}
+}
+
 // NewIPParameterQualifier returns a new block.
 func NewIPParameterQualifier() *IPParameter {
 	return &IPParameter{
@@ -52,6 +66,9 @@ func (i *IPParameter) UnmarshalBinary(b []byte) error {
 	if err := i.header.unmarshalBinary(b); err != nil {
 		return err
 	}
+	if err := i.header.checkLength(12); err != nil {
+		return err
+	}
 
 	offset := i.header.len()
 
@@ -79,13 +96,13 @@ func (i *IPParameter) MarshalBinary() ([]byte, error) {
 	copy(b[offset:], bh)
 	offset += i.header.len()
 
-	copy(b[offset:offset+4], i.IPAddress)
+	putIP(b[offset:offset+4], i.IPAddress)
 	offset += 4
 
-	copy(b[offset:offset+4], i.Subnetmask)
+	putIP(b[offset:offset+4], i.Subnetmask)
 	offset += 4
 
-	copy(b[offset:offset+4], i.StandardGateway)
+	putIP(b[offset:offset+4], i.StandardGateway)
 
 	return b, nil
 }
@@ -94,3 +111,11 @@ func (i *IPParameter) MarshalBinary() ([]byte, error) {
 func (i *IPParameter) Len() int {
 	return i.header.len() + 4 + 4 + 4
 }
+
+// putIP copies the four byte form of ip into b.
+func putIP(b []byte, ip net.IP) {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	copy(b, ip)
+}
@@ -54,3 +54,43 @@ func TestIPIPParameterMarshalBinary(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestIPIPParameterUnmarshalBinaryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		err  string
+	}{
+		{
+			name: "truncated header",
+			b:    []byte{0x01, 0x02, 0x00},
+			err:  ErrTruncated.Error(),
+		},
+		{
+			name: "truncated data",
+			b: []byte{
+				0x01, 0x02, 0x00, 0x0e, 0x00, 0x01, 0xac, 0x13,
+			},
+			err: ErrTruncated.Error(),
+		},
+		{
+			name: "length mismatch",
+			b: []byte{
+				0x01, 0x02, 0x00, 0x06, 0x00, 0x01, 0xac, 0x13,
+				0x68, 0x05,
+			},
+			err: "block: invalid length 6 for option 0x01 suboption 0x02",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &IPParameter{}
+			i.HasInfo = true
+			err := i.UnmarshalBinary(tt.b)
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("expected %s; got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package block
+
+import (
+	"net"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// MACAddress is a mac address block.
+type MACAddress struct {
+	header
+	MACAddress net.HardwareAddr
+}
+
+var _ Block = &MACAddress{}
+
+// NewMACAddressWithInfo returns a new block.
+func NewMACAddressWithInfo(mac net.HardwareAddr, info uint16) *MACAddress {
+	return &MACAddress{
+		header: header{
+			Option:       option.IP,
+			Suboption:    suboption.MACAddress,
+			Length:       8,
+			HasInfo:      true,
+			Info:         info,
+			HasQualifier: false,
+		},
+		MACAddress: mac,
+	}
+}
+
+// UnmarshalBinary turns bytes into struct.
+func (m *MACAddress) UnmarshalBinary(b []byte) error {
+	if err := m.header.unmarshalBinary(b); err != nil {
+		return err
+	}
+	if err := m.header.checkLength(6); err != nil {
+		return err
+	}
+
+	i := m.header.len()
+	m.MACAddress = net.HardwareAddr(b[i : i+6])
+
+	return nil
+}
+
+// MarshalBinary converts struct into byte slice.
+func (m *MACAddress) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.Len())
+
+	bh, err := m.header.marshalBinary()
+	if err != nil {
+		return b, err
+	}
+	offset := 0
+
+	copy(b[offset:], bh)
+	offset += m.header.len()
+
+	copy(b[offset:offset+6], m.MACAddress)
+
+	return b, nil
+}
+
+// Len returns length for mac address block.
+func (m *MACAddress) Len() int {
+	return m.header.len() + 6
+}
@@ -0,0 +1,41 @@
+package block
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIPMACAddressUnmarshalBinary(t *testing.T) {
+	b := []byte{
+		0x01, 0x01, 0x00, 0x08, 0x00, 0x00, 0x00, 0x09,
+		0xe5, 0x00, 0x9a, 0x20,
+	}
+
+	m := &MACAddress{}
+	m.HasInfo = true
+
+	if err := m.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	}
+	if m.MACAddress.String() != "00:09:e5:00:9a:20" {
+		t.Errorf("expected %s; got %s", "00:09:e5:00:9a:20", m.MACAddress)
+	}
+}
+
+func TestIPMACAddressMarshalBinary(t *testing.T) {
+	m := NewMACAddressWithInfo(net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}, 0)
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	expected := []byte{
+		0x01, 0x01, 0x00, 0x08, 0x00, 0x00, 0x00, 0x09,
+		0xe5, 0x00, 0x9a, 0x20,
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+}
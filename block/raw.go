@@ -0,0 +1,54 @@
+package block
+
+// Raw is a block without a registered block type. It keeps the undecoded
+// payload so the block can be displayed and encoded again.
+type Raw struct {
+	header
+	Data []byte
+}
+
+var _ Block = &Raw{}
+
+// NewRaw returns a new block.
+func NewRaw(hasInfo, hasQualifier bool) *Raw {
+	return &Raw{
+		header: header{
+			HasInfo:      hasInfo,
+			HasQualifier: hasQualifier,
+		},
+	}
+}
+
+// UnmarshalBinary turns bytes into struct.
+func (r *Raw) UnmarshalBinary(b []byte) error {
+	if err := r.header.unmarshalBinary(b); err != nil {
+		return err
+	}
+
+	r.Data = append([]byte(nil), r.header.payload(b)...)
+
+	return nil
+}
+
+// MarshalBinary converts struct into byte slice.
+func (r *Raw) MarshalBinary() ([]byte, error) {
+	b := make([]byte, r.Len())
+
+	bh, err := r.header.marshalBinary()
+	if err != nil {
+		return b, err
+	}
+	offset := 0
+
+	copy(b[offset:], bh)
+	offset += r.header.len()
+
+	copy(b[offset:], r.Data)
+
+	return b, nil
+}
+
+// Len returns length for raw block.
+func (r *Raw) Len() int {
+	return r.header.len() + len(r.Data)
+}
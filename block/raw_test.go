@@ -0,0 +1,50 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+func TestRawUnmarshalBinary(t *testing.T) {
+	b := []byte{
+		0x02, 0x04, 0x00, 0x04, 0x00, 0x00, 0x02, 0x00,
+	}
+
+	r := NewRaw(true, false)
+	if err := r.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	}
+	if r.Option != option.Properties {
+		t.Errorf("expected %d; got %d", option.Properties, r.Option)
+	}
+	if r.Suboption != suboption.DeviceRole {
+		t.Errorf("expected %d; got %d", suboption.DeviceRole, r.Suboption)
+	}
+	if diff := cmp.Diff(r.Data, []byte{0x02, 0x00}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestRawMarshalBinary(t *testing.T) {
+	expected := []byte{
+		0x02, 0x04, 0x00, 0x04, 0x00, 0x00, 0x02, 0x00,
+	}
+
+	r := NewRaw(true, false)
+	if err := r.UnmarshalBinary(expected); err != nil {
+		t.Error(err)
+	}
+	b, err := r.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+	if r.Len() != 8 {
+		t.Errorf("expected %d; got %d", 8, r.Len())
+	}
+}
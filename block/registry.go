@@ -0,0 +1,139 @@
+package block
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// Factory returns a new empty block. hasInfo and hasQualifier tell whether
+// the block header carries block info or a block qualifier.
+type Factory func(hasInfo, hasQualifier bool) Block
+
+type key struct {
+	option    option.Option
+	suboption suboption.Suboption
+}
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[key]Factory)
+)
+
+func init() {
+	builtin(option.All, suboption.All, func(h header) Block {
+		return &All{header: h}
+	})
+	builtin(option.IP, suboption.MACAddress, func(h header) Block {
+		return &MACAddress{header: h}
+	})
+	builtin(option.IP, suboption.IPParameter, func(h header) Block {
+		return &IPParameter{header: h}
+	})
+	builtin(option.IP, suboption.FullIPSuite, func(h header) Block {
+		return &FullIPSuite{header: h}
+	})
+	builtin(option.Properties, suboption.ManufacturerSpecific, func(h header) Block {
+		return &ManufacturerSpecific{header: h}
+	})
+	builtin(option.Properties, suboption.NameOfStation, func(h header) Block {
+		return &NameOfStation{header: h}
+	})
+	builtin(option.Properties, suboption.DeviceID, func(h header) Block {
+		return &DeviceID{header: h}
+	})
+	builtin(option.Properties, suboption.DeviceRole, func(h header) Block {
+		return &DeviceRole{header: h}
+	})
+	builtin(option.Properties, suboption.DeviceOptions, func(h header) Block {
+		return &DeviceOptions{header: h}
+	})
+	builtin(option.Properties, suboption.AliasName, func(h header) Block {
+		return &AliasName{header: h}
+	})
+	builtin(option.Properties, suboption.DeviceInstance, func(h header) Block {
+		return &DeviceInstance{header: h}
+	})
+	builtin(option.Properties, suboption.OEMDeviceID, func(h header) Block {
+		return &OEMDeviceID{header: h}
+	})
+	for _, subopt := range []suboption.Suboption{
+		suboption.HostName,
+		suboption.VendorSpecificInformation,
+		suboption.ServerIdentifier,
+		suboption.ParameterRequestList,
+		suboption.ClassIdentifier,
+		suboption.DHCPClientIdentifier,
+		suboption.FullyQualifiedDomainName,
+		suboption.UUIDClientIdentifier,
+		suboption.DHCP,
+	} {
+		builtin(option.DHCP, subopt, func(h header) Block {
+			return &DHCP{header: h}
+		})
+	}
+	builtin(option.Control, suboption.Start, func(h header) Block {
+		return &Control{header: h}
+	})
+	builtin(option.Control, suboption.Stop, func(h header) Block {
+		return &Control{header: h}
+	})
+	builtin(option.Control, suboption.FactoryReset, func(h header) Block {
+		return &Control{header: h}
+	})
+	builtin(option.Control, suboption.ResetToFactory, func(h header) Block {
+		return &Control{header: h}
+	})
+	builtin(option.Control, suboption.Signal, func(h header) Block {
+		return &Signal{header: h}
+	})
+	builtin(option.Control, suboption.Response, func(h header) Block {
+		return &ControlResponse{header: h}
+	})
+	builtin(option.Initiative, suboption.DeviceInitiative, func(h header) Block {
+		return &DeviceInitiative{header: h}
+	})
+}
+
+// builtin registers a block type of this package.
+func builtin(opt option.Option, subopt suboption.Suboption, f func(h header) Block) {
+	Register(opt, subopt, func(hasInfo, hasQualifier bool) Block {
+		return f(header{
+			HasInfo:      hasInfo,
+			HasQualifier: hasQualifier,
+		})
+	})
+}
+
+// Register makes a block type available for decoding. Vendor specific blocks
+// use options 0x80 to 0xfe. Register panics if it is called twice for the
+// same option and suboption or if f is nil. Blocks returned by f should
+// implement Typer, for example by embedding a block of this package.
+func Register(opt option.Option, subopt suboption.Suboption, f Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if f == nil {
+		panic("block: Register factory is nil")
+	}
+	k := key{option: opt, suboption: subopt}
+	if _, dup := factories[k]; dup {
+		panic(fmt.Sprintf("block: Register called twice for option %#02x suboption %#02x", opt, subopt))
+	}
+	factories[k] = f
+}
+
+// New returns a new empty block for option and suboption. It reports false if
+// no block type is registered for them.
+func New(opt option.Option, subopt suboption.Suboption, hasInfo, hasQualifier bool) (Block, bool) {
+	factoriesMu.RLock()
+	f, ok := factories[key{option: opt, suboption: subopt}]
+	factoriesMu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+	return f(hasInfo, hasQualifier), true
+}
@@ -0,0 +1,61 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// vendor is a vendor specific block for testing.
+type vendor struct {
+	All
+}
+
+func TestRegistryNew(t *testing.T) {
+	b, ok := New(option.Properties, suboption.NameOfStation, true, false)
+	if !ok {
+		t.Fatal("expected name of station block to be registered")
+	}
+	nos, ok := b.(*NameOfStation)
+	if !ok {
+		t.Fatalf("expected *NameOfStation; got %T", b)
+	}
+	if !nos.HasInfo {
+		t.Error("expected block with info")
+	}
+	if nos.HasQualifier {
+		t.Error("expected block without qualifier")
+	}
+
+	if _, ok := New(option.Option(0x81), suboption.Suboption(0x01), false, false); ok {
+		t.Error("expected no block for unregistered option")
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	t.Cleanup(func() {
+		unregister(option.Option(0x80), suboption.Suboption(0x01))
+	})
+
+	Register(option.Option(0x80), suboption.Suboption(0x01), func(hasInfo, hasQualifier bool) Block {
+		return &vendor{}
+	})
+
+	b, ok := New(option.Option(0x80), suboption.Suboption(0x01), false, false)
+	if !ok {
+		t.Fatal("expected vendor block to be registered")
+	}
+	if _, ok := b.(*vendor); !ok {
+		t.Errorf("expected *vendor; got %T", b)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected duplicate registration to panic")
+		}
+	}()
+	Register(option.Option(0x80), suboption.Suboption(0x01), func(hasInfo, hasQualifier bool) Block {
+		return &vendor{}
+	})
+}
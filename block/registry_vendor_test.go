@@ -0,0 +1,65 @@
+package block_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zemirco/dcp"
+	"github.com/zemirco/dcp/block"
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// vendorBlock is a vendor specific block of another package.
+type vendorBlock struct {
+	*block.Raw
+}
+
+func TestRegistryVendorBlock(t *testing.T) {
+	opt, subopt := option.Option(0x80), suboption.Suboption(0x02)
+	t.Cleanup(func() {
+		block.Unregister(opt, subopt)
+	})
+
+	block.Register(opt, subopt, func(hasInfo, hasQualifier bool) block.Block {
+		return &vendorBlock{Raw: block.NewRaw(hasInfo, hasQualifier)}
+	})
+
+	// identify response with a vendor specific block and a device initiative
+	// block
+	b := []byte{
+		0xfe, 0xff, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+		0x00, 0x00, 0x00, 0x12, 0x80, 0x02, 0x00, 0x06,
+		0x00, 0x00, 0xca, 0xfe, 0xba, 0xbe, 0x06, 0x01,
+		0x00, 0x04, 0x00, 0x00, 0x00, 0x01,
+	}
+
+	var tg dcp.Telegram
+	if err := tg.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if len(tg.Blocks) != 2 {
+		t.Fatalf("expected %d; got %d", 2, len(tg.Blocks))
+	}
+	v, ok := tg.Blocks[0].(*vendorBlock)
+	if !ok {
+		t.Fatalf("expected *vendorBlock; got %T", tg.Blocks[0])
+	}
+	if o, s := v.Type(); o != opt || s != subopt {
+		t.Errorf("expected %#02x %#02x; got %#02x %#02x", opt, subopt, o, s)
+	}
+	if diff := cmp.Diff(v.Data, []byte{0xca, 0xfe, 0xba, 0xbe}); diff != "" {
+		t.Error(diff)
+	}
+	if tg.DeviceInitiative() == nil {
+		t.Error("expected device initiative block")
+	}
+
+	encoded, err := tg.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(encoded, b); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,378 @@
+package dcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/zemirco/dcp/block"
+)
+
+// Client sends requests and collects the matching responses.
+// Requests are serialized so a client can be shared between goroutines.
+type Client struct {
+	conn *Conn
+	mu   sync.Mutex
+}
+
+// NewClient returns a new client using the given connection.
+func NewClient(conn *Conn) *Client {
+	return &Client{
+		conn: conn,
+	}
+}
+
+// IdentifyOptions configure an identify request.
+type IdentifyOptions struct {
+	// ResponseDelay is the response delay factor sent to the devices.
+	// Zero means 255.
+	ResponseDelay uint16
+
+	// Filter limits the request to devices matching all blocks.
+	// See NewIdentifyRequest.
+	Filter []block.Block
+}
+
+// Device is a device that answered an identify request.
+type Device struct {
+	MAC      net.HardwareAddr
+	Response *Frame
+}
+
+// Identify sends an identify request and collects all responses until the
+// response delay window or the context expires. Every device is returned
+// once, in the order it answered.
+func (c *Client) Identify(ctx context.Context, opts *IdentifyOptions) ([]*Device, error) {
+	if opts == nil {
+		opts = &IdentifyOptions{}
+	}
+
+	request := NewIdentifyRequest(c.conn.HardwareAddr(), opts.Filter...)
+	if opts.ResponseDelay != 0 {
+		request.ResponseDelay = opts.ResponseDelay
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := c.conn.WriteFrame(request); err != nil {
+		return nil, err
+	}
+
+	stop, _, err := deadline(ctx, c.conn, time.Now().Add(IdentifyWindow(request.ResponseDelay)))
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+
+	var devices []*Device
+	seen := make(map[string]bool)
+
+	for {
+		b, err := c.conn.read()
+		if err != nil {
+			if isTimeout(err) || ctx.Err() != nil {
+				return devices, nil
+			}
+			return devices, err
+		}
+
+		// ignore malformed frames from other stations
+		response, err := c.conn.decode(b)
+		if err != nil {
+			continue
+		}
+
+		if !isResponse(request, response) {
+			continue
+		}
+
+		mac := response.Source.String()
+		if seen[mac] {
+			continue
+		}
+		seen[mac] = true
+
+		devices = append(devices, &Device{
+			MAC:      response.Source,
+			Response: response,
+		})
+	}
+}
+
+// SetNameOfStation assigns a name to the device with the given mac address.
+// Without permanent the device forgets the name after a power cycle.
+func (c *Client) SetNameOfStation(ctx context.Context, mac net.HardwareAddr, name string, permanent bool) error {
+	qualifier := block.QualifierTemporary
+	if permanent {
+		qualifier = block.QualifierPermanent
+	}
+
+	b := block.NewNameOfStationQualifier(qualifier, name)
+	request := NewSetNameOfStationRequest(mac, c.conn.HardwareAddr(), b)
+	response, err := c.roundTrip(ctx, request)
+	if err != nil {
+		return err
+	}
+	return firstErr(setResults(request, response))
+}
+
+// Signal lets the device with the given mac address flash its signal LED.
+func (c *Client) Signal(ctx context.Context, mac net.HardwareAddr) error {
+	request := NewSignalRequest(mac, c.conn.HardwareAddr())
+	response, err := c.roundTrip(ctx, request)
+	if err != nil {
+		return err
+	}
+	return firstErr(setResults(request, response))
+}
+
+// SignalRepeat signals the device every interval until the context is
+// canceled. Zero means every three seconds, which is how long a device
+// flashes for a single signal. It returns nil when the context ends.
+func (c *Client) SignalRepeat(ctx context.Context, mac net.HardwareAddr, interval time.Duration) error {
+	if interval == 0 {
+		interval = 3 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Signal(ctx, mac); err != nil {
+			if ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) {
+				return nil
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// FactoryReset resets the device with the given mac address to its factory
+// settings. confirm must be the current name of station of the device so a
+// typo in the mac address cannot reset another device.
+func (c *Client) FactoryReset(ctx context.Context, mac net.HardwareAddr, confirm string) error {
+	if err := c.confirm(ctx, mac, confirm); err != nil {
+		return err
+	}
+	request := NewFactoryResetRequest(mac, c.conn.HardwareAddr())
+	response, err := c.roundTrip(ctx, request)
+	if err != nil {
+		return err
+	}
+	return firstErr(setResults(request, response))
+}
+
+// ResetToFactory resets the data selected by mode on the device with the
+// given mac address. confirm must be the current name of station of the
+// device so a typo in the mac address cannot reset another device.
+func (c *Client) ResetToFactory(ctx context.Context, mac net.HardwareAddr, mode block.ResetMode, confirm string) error {
+	if err := c.confirm(ctx, mac, confirm); err != nil {
+		return err
+	}
+	request := NewResetToFactoryRequest(mac, c.conn.HardwareAddr(), mode)
+	response, err := c.roundTrip(ctx, request)
+	if err != nil {
+		return err
+	}
+	return firstErr(setResults(request, response))
+}
+
+// confirm verifies that the device with the given mac address is named
+// name of station.
+func (c *Client) confirm(ctx context.Context, mac net.HardwareAddr, nameOfStation string) error {
+	if nameOfStation == "" {
+		return ErrNotConfirmed
+	}
+
+	devices, err := c.Identify(ctx, &IdentifyOptions{
+		ResponseDelay: 1,
+		Filter:        []block.Block{block.NewNameOfStationFilter(nameOfStation)},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, d := range devices {
+		nos := d.Response.NameOfStation()
+		if bytes.Equal(d.MAC, mac) && nos != nil && nos.NameOfStation == nameOfStation {
+			return nil
+		}
+	}
+	return ErrNotConfirmed
+}
+
+// Get reads the selected blocks from the device with the given mac address.
+// It returns one result per selector with either the block or the error the
+// device returned for it, and the first error of all results.
+func (c *Client) Get(ctx context.Context, mac net.HardwareAddr, selectors ...BlockSelector) ([]Result, error) {
+	request := NewGetRequest(mac, c.conn.HardwareAddr(), selectors)
+	response, err := c.roundTrip(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	results := getResults(request, response)
+	return results, firstErr(results)
+}
+
+// Set applies the blocks to the device with the given mac address in a single
+// transaction. It returns one result per block including the start and stop
+// control blocks, and the first error of all results.
+func (c *Client) Set(ctx context.Context, mac net.HardwareAddr, blocks ...block.Block) ([]Result, error) {
+	request := NewSetTransaction(mac, c.conn.HardwareAddr(), blocks...)
+	response, err := c.roundTrip(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	results := setResults(request, response)
+	return results, firstErr(results)
+}
+
+// roundTrip sends a request to a single device and returns its response.
+// It waits until the context expires or requestTimeout passes.
+func (c *Client) roundTrip(ctx context.Context, request *Frame) (*Frame, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := c.conn.WriteFrame(request); err != nil {
+		return nil, err
+	}
+
+	stop, fromContext, err := deadline(ctx, c.conn, time.Now().Add(requestTimeout))
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+
+	for {
+		b, err := c.conn.read()
+		if err != nil {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if !isTimeout(err) {
+				return nil, err
+			}
+			// the read deadline may fire right before the context notices
+			// that its deadline passed
+			if fromContext {
+				return nil, context.DeadlineExceeded
+			}
+			return nil, ErrNoResponse
+		}
+
+		// ignore malformed frames from other stations
+		response, err := c.conn.decode(b)
+		if err != nil {
+			continue
+		}
+
+		if isResponse(request, response) && bytes.Equal(response.Source, request.Destination) {
+			return response, nil
+		}
+	}
+}
+
+// deadline sets the read deadline of the connection to the given time or the
+// context deadline, whatever comes first, and reports whether it is the
+// context deadline. A zero time means no deadline. Canceling the context
+// unblocks pending reads. The returned function must be called once reading
+// is done.
+func deadline(ctx context.Context, conn *Conn, t time.Time) (func(), bool, error) {
+	fromContext := false
+	if d, ok := ctx.Deadline(); ok && (t.IsZero() || d.Before(t)) {
+		t = d
+		fromContext = true
+	}
+	if err := conn.SetReadDeadline(t); err != nil {
+		return nil, false, err
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+		conn.SetReadDeadline(time.Time{})
+	}, fromContext, nil
+}
+
+// requestTimeout is how long to wait for the response to a get or set
+// request.
+const requestTimeout = 2 * time.Second
+
+// MaxResponseDelay is the largest valid response delay factor.
+const MaxResponseDelay = 0x1900
+
+// identifyMargin is the time a device may take to process an identify
+// request on top of its response delay.
+const identifyMargin = 400 * time.Millisecond
+
+// IdentifyResponseDelay returns how long the device with the given mac
+// address waits before it answers an identify request with the given
+// response delay factor. Devices spread their responses over slots of 10ms.
+// The slot is the last two bytes of the mac address modulo the factor.
+// Factors of zero and one mean no delay.
+func IdentifyResponseDelay(mac net.HardwareAddr, factor uint16) time.Duration {
+	if factor > MaxResponseDelay {
+		factor = MaxResponseDelay
+	}
+	if factor <= 1 || len(mac) < 2 {
+		return 0
+	}
+	slot := binary.BigEndian.Uint16(mac[len(mac)-2:]) % factor
+	return time.Duration(slot) * 10 * time.Millisecond
+}
+
+// IdentifyWindow returns how long a client waits for identify responses
+// with the given response delay factor. It covers the last slot any device
+// may pick plus the processing time of the device.
+func IdentifyWindow(factor uint16) time.Duration {
+	if factor > MaxResponseDelay {
+		factor = MaxResponseDelay
+	}
+	if factor <= 1 {
+		return identifyMargin
+	}
+	return time.Duration(factor-1)*10*time.Millisecond + identifyMargin
+}
+
+// isResponse reports whether response answers request.
+func isResponse(request, response *Frame) bool {
+	return response.ServiceType == Response &&
+		response.ServiceID == request.ServiceID &&
+		response.XID == request.XID
+}
+
+// isTimeout reports whether err is a read deadline error.
+func isTimeout(err error) bool {
+	t, ok := err.(interface{ Timeout() bool })
+	return ok && t.Timeout()
+}
@@ -0,0 +1,502 @@
+package dcp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/zemirco/dcp/block"
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// identifyResponse returns the bytes of an identify response with a single
+// name of station block.
+func identifyResponse(dst, src net.HardwareAddr, xid uint32, name string) []byte {
+	b := make([]byte, 14+12+6)
+	copy(b[0:6], dst)
+	copy(b[6:12], src)
+	binary.BigEndian.PutUint16(b[12:14], 0x8892)
+	binary.BigEndian.PutUint16(b[14:16], uint16(IdentifyResponse))
+	b[16] = byte(Identify)
+	b[17] = byte(Response)
+	binary.BigEndian.PutUint32(b[18:22], xid)
+
+	length := 6 + len(name)
+	if length%2 != 0 {
+		length++
+	}
+	binary.BigEndian.PutUint16(b[24:26], uint16(length))
+	b[26] = 0x02
+	b[27] = 0x02
+	binary.BigEndian.PutUint16(b[28:30], uint16(len(name)+2))
+	b = append(b, name...)
+	if len(name)%2 != 0 {
+		b = append(b, 0x00)
+	}
+	return b
+}
+
+func TestClientIdentify(t *testing.T) {
+	source := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+	first := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+	second := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x21}
+
+	ft := newFakeTransport(source)
+	c := NewClient(NewConn(ft))
+
+	go func() {
+		var request Frame
+		if err := request.UnmarshalBinary(<-ft.out); err != nil {
+			panic(err)
+		}
+		ft.in <- identifyResponse(source, first, request.XID, "first")
+		ft.in <- identifyResponse(source, first, request.XID, "first")
+		ft.in <- identifyResponse(source, second, request.XID+1, "other")
+		ft.in <- identifyResponse(source, second, request.XID, "second")
+	}()
+
+	devices, err := c.Identify(context.Background(), &IdentifyOptions{ResponseDelay: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("expected %d; got %d", 2, len(devices))
+	}
+	if devices[0].MAC.String() != first.String() {
+		t.Errorf("expected %s; got %s", first, devices[0].MAC)
+	}
+	if devices[1].MAC.String() != second.String() {
+		t.Errorf("expected %s; got %s", second, devices[1].MAC)
+	}
+}
+
+func TestClientIdentifyContext(t *testing.T) {
+	ft := newFakeTransport(net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21})
+	c := NewClient(NewConn(ft))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	devices, err := c.Identify(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 0 {
+		t.Errorf("expected %d; got %d", 0, len(devices))
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected identify to stop with the context; took %s", elapsed)
+	}
+}
+
+// setResponse returns the bytes of a set response with a single control
+// response block.
+func setResponse(dst, src net.HardwareAddr, xid uint32, opt option.Option, subopt suboption.Suboption, code uint8) []byte {
+	b := make([]byte, 14+12+8)
+	copy(b[0:6], dst)
+	copy(b[6:12], src)
+	binary.BigEndian.PutUint16(b[12:14], 0x8892)
+	binary.BigEndian.PutUint16(b[14:16], uint16(GetSet))
+	b[16] = byte(Set)
+	b[17] = byte(Response)
+	binary.BigEndian.PutUint32(b[18:22], xid)
+	binary.BigEndian.PutUint16(b[24:26], 8)
+	copy(b[26:], []byte{0x05, 0x04, 0x00, 0x03, byte(opt), byte(subopt), code, 0x00})
+	return b
+}
+
+func TestClientSetNameOfStation(t *testing.T) {
+	source := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+	device := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+
+	tests := []struct {
+		name string
+		code uint8
+		err  string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name: "error",
+			code: 0x03,
+			err:  "block: suboption not set for option 0x02 suboption 0x02",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := newFakeTransport(source)
+			c := NewClient(NewConn(ft))
+
+			go func() {
+				var request Frame
+				if err := request.UnmarshalBinary(<-ft.out); err != nil {
+					panic(err)
+				}
+				nos := request.NameOfStation()
+				if nos == nil || nos.NameOfStation != "zeiss" || nos.Qualifier != block.QualifierPermanent {
+					panic("unexpected request")
+				}
+				// response from another device is ignored
+				ft.in <- setResponse(source, source, request.XID, option.Properties, suboption.NameOfStation, 0x04)
+				ft.in <- setResponse(source, device, request.XID, option.Properties, suboption.NameOfStation, tt.code)
+			}()
+
+			err := c.SetNameOfStation(context.Background(), device, "zeiss", true)
+			if tt.err == "" && err != nil {
+				t.Errorf("expected no error; got %v", err)
+			}
+			if tt.err != "" && (err == nil || err.Error() != tt.err) {
+				t.Errorf("expected %s; got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestClientSetNameOfStationNoResponse(t *testing.T) {
+	ft := newFakeTransport(net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21})
+	c := NewClient(NewConn(ft))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := c.SetNameOfStation(ctx, net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}, "zeiss", false)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected %v; got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestClientSet(t *testing.T) {
+	source := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+	device := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+
+	ft := newFakeTransport(source)
+	c := NewClient(NewConn(ft))
+
+	go func() {
+		var request Frame
+		if err := request.UnmarshalBinary(<-ft.out); err != nil {
+			panic(err)
+		}
+		response := Frame{
+			EthernetII: *NewEthernetII(source, device),
+			Telegram: Telegram{
+				FrameID:     GetSet,
+				ServiceID:   Set,
+				ServiceType: Response,
+				XID:         request.XID,
+			},
+		}
+		for _, r := range []struct {
+			opt    option.Option
+			subopt suboption.Suboption
+			code   block.BlockError
+		}{
+			{option.Control, suboption.Start, block.NoError},
+			{option.Properties, suboption.NameOfStation, block.NoError},
+			{option.IP, suboption.IPParameter, block.InOperation},
+			{option.Control, suboption.Stop, block.NoError},
+		} {
+			response.Blocks = append(response.Blocks, block.NewControlResponseWithError(r.opt, r.subopt, r.code))
+		}
+		b, err := response.MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		ft.in <- b
+	}()
+
+	results, err := c.Set(context.Background(), device,
+		block.NewNameOfStationQualifier(block.QualifierTemporary, "zeiss"),
+		block.NewIPParameterQualifier(),
+	)
+	if !errors.Is(err, block.ErrInOperation) {
+		t.Errorf("expected %v; got %v", block.ErrInOperation, err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected %d; got %d", 4, len(results))
+	}
+	for i, r := range results {
+		if i == 2 {
+			if r.Suboption != suboption.IPParameter || !errors.Is(r.Err, block.ErrInOperation) {
+				t.Errorf("expected in operation for ip parameter; got %v", r)
+			}
+			continue
+		}
+		if r.Err != nil {
+			t.Errorf("expected no error for block %d; got %v", i, r.Err)
+		}
+	}
+}
+
+// answerSet answers every set request with successful control responses
+// until done is closed. It returns the number of requests.
+func answerSet(ft *fakeTransport, device net.HardwareAddr, done chan struct{}) chan int {
+	count := make(chan int, 1)
+	go func() {
+		n := 0
+		for {
+			select {
+			case <-done:
+				count <- n
+				return
+			case b := <-ft.out:
+				var request Frame
+				if err := request.UnmarshalBinary(b); err != nil {
+					panic(err)
+				}
+				n++
+				response := Frame{
+					EthernetII: *NewEthernetII(request.Source, device),
+					Telegram: Telegram{
+						FrameID:     GetSet,
+						ServiceID:   Set,
+						ServiceType: Response,
+						XID:         request.XID,
+					},
+				}
+				for _, blk := range request.Blocks {
+					opt, subopt := blockType(blk)
+					response.Blocks = append(response.Blocks, block.NewControlResponseWithError(opt, subopt, block.NoError))
+				}
+				b, err := response.MarshalBinary()
+				if err != nil {
+					panic(err)
+				}
+				ft.in <- b
+			}
+		}
+	}()
+	return count
+}
+
+func TestClientSignal(t *testing.T) {
+	device := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+	ft := newFakeTransport(net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21})
+	c := NewClient(NewConn(ft))
+
+	done := make(chan struct{})
+	count := answerSet(ft, device, done)
+
+	if err := c.Signal(context.Background(), device); err != nil {
+		t.Error(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := c.SignalRepeat(ctx, device, 20*time.Millisecond); err != nil {
+		t.Error(err)
+	}
+
+	close(done)
+	if n := <-count; n < 3 {
+		t.Errorf("expected at least %d signals; got %d", 3, n)
+	}
+}
+
+func TestClientResetToFactory(t *testing.T) {
+	source := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+	device := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+
+	tests := []struct {
+		name    string
+		confirm string
+		err     error
+	}{
+		{
+			name:    "confirmed",
+			confirm: "press-1",
+		},
+		{
+			name:    "other name",
+			confirm: "press-2",
+			err:     ErrNotConfirmed,
+		},
+		{
+			name: "empty",
+			err:  ErrNotConfirmed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := newFakeTransport(source)
+			c := NewClient(NewConn(ft))
+
+			resets := make(chan *block.Control, 1)
+			go func() {
+				for b := range ft.out {
+					var request Frame
+					if err := request.UnmarshalBinary(b); err != nil {
+						panic(err)
+					}
+					switch request.ServiceID {
+					case Identify:
+						if nos := request.NameOfStation(); nos != nil && nos.NameOfStation == "press-1" {
+							ft.in <- identifyResponse(source, device, request.XID, "press-1")
+						}
+					case Set:
+						resets <- request.Blocks[0].(*block.Control)
+						ft.in <- setResponse(source, device, request.XID, option.Control, suboption.ResetToFactory, 0)
+					}
+				}
+			}()
+			defer close(ft.out)
+
+			err := c.ResetToFactory(context.Background(), device, block.ResetCommunication, tt.confirm)
+			if err != tt.err {
+				t.Fatalf("expected %v; got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			reset := <-resets
+			if reset.Suboption != suboption.ResetToFactory {
+				t.Errorf("expected %d; got %d", suboption.ResetToFactory, reset.Suboption)
+			}
+			if reset.Qualifier != uint16(block.ResetCommunication) {
+				t.Errorf("expected %d; got %d", block.ResetCommunication, reset.Qualifier)
+			}
+		})
+	}
+}
+
+func TestClientGet(t *testing.T) {
+	source := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+	device := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+
+	ft := newFakeTransport(source)
+	c := NewClient(NewConn(ft))
+
+	go func() {
+		var request Frame
+		if err := request.UnmarshalBinary(<-ft.out); err != nil {
+			panic(err)
+		}
+		b := []byte{
+			0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21, 0x00, 0x09,
+			0xe5, 0x00, 0x9a, 0x20, 0x88, 0x92, 0xfe, 0xfd,
+			0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x26, 0x02, 0x02, 0x00, 0x07, 0x00, 0x00,
+			0x7a, 0x65, 0x69, 0x73, 0x73, 0x00, 0x01, 0x02,
+			0x00, 0x0e, 0x00, 0x01, 0xac, 0x13, 0x68, 0x05,
+			0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x05, 0x04, 0x00, 0x03, 0x02, 0x03, 0x02, 0x00,
+		}
+		binary.BigEndian.PutUint32(b[18:22], request.XID)
+		ft.in <- b
+	}()
+
+	results, err := c.Get(context.Background(), device,
+		BlockSelector{Option: option.Properties, Suboption: suboption.NameOfStation},
+		BlockSelector{Option: option.IP, Suboption: suboption.IPParameter},
+		BlockSelector{Option: option.Properties, Suboption: suboption.DeviceID},
+	)
+	if !errors.Is(err, block.ErrSuboptionNotSupported) {
+		t.Errorf("expected %v; got %v", block.ErrSuboptionNotSupported, err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected %d; got %d", 3, len(results))
+	}
+	if nos, ok := results[0].Block.(*block.NameOfStation); !ok || nos.NameOfStation != "zeiss" || results[0].Err != nil {
+		t.Errorf("expected name of station zeiss; got %v", results[0])
+	}
+	if ip, ok := results[1].Block.(*block.IPParameter); !ok || ip.IPAddress.String() != "172.19.104.5" || results[1].Err != nil {
+		t.Errorf("expected ip parameter 172.19.104.5; got %v", results[1])
+	}
+	if results[2].Block != nil || !errors.Is(results[2].Err, block.ErrSuboptionNotSupported) {
+		t.Errorf("expected suboption not supported; got %v", results[2])
+	}
+}
+
+func TestClientGetMACAddress(t *testing.T) {
+	source := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+	device := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+
+	ft := newFakeTransport(source)
+	c := NewClient(NewConn(ft))
+
+	go func() {
+		var request Frame
+		if err := request.UnmarshalBinary(<-ft.out); err != nil {
+			panic(err)
+		}
+		response := Frame{
+			EthernetII: *NewEthernetII(source, device),
+			Telegram: Telegram{
+				FrameID:     GetSet,
+				ServiceID:   Get,
+				ServiceType: Response,
+				XID:         request.XID,
+				Blocks: []block.Block{
+					block.NewMACAddressWithInfo(device, 0),
+				},
+			},
+		}
+		b, err := response.MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		ft.in <- b
+	}()
+
+	results, err := c.Get(context.Background(), device, BlockSelector{Option: option.IP, Suboption: suboption.MACAddress})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, ok := results[0].Block.(*block.MACAddress)
+	if !ok {
+		t.Fatalf("expected *block.MACAddress; got %T", results[0].Block)
+	}
+	if m.MACAddress.String() != device.String() {
+		t.Errorf("expected %s; got %s", device, m.MACAddress)
+	}
+}
+
+func TestIdentifyResponseDelay(t *testing.T) {
+	mac := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+
+	tests := []struct {
+		factor   uint16
+		expected time.Duration
+	}{
+		{0, 0},
+		{1, 0},
+		{100, 560 * time.Millisecond},
+		{255, 1860 * time.Millisecond},
+		{0xffff, 10560 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		if d := IdentifyResponseDelay(mac, tt.factor); d != tt.expected {
+			t.Errorf("factor %d: expected %s; got %s", tt.factor, tt.expected, d)
+		}
+		if d := IdentifyResponseDelay(mac, tt.factor); d >= IdentifyWindow(tt.factor) {
+			t.Errorf("factor %d: delay %s exceeds window %s", tt.factor, d, IdentifyWindow(tt.factor))
+		}
+	}
+}
+
+func TestIdentifyWindow(t *testing.T) {
+	tests := []struct {
+		factor   uint16
+		expected time.Duration
+	}{
+		{0, 400 * time.Millisecond},
+		{1, 400 * time.Millisecond},
+		{255, 2940 * time.Millisecond},
+		{0xffff, 64390 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		if d := IdentifyWindow(tt.factor); d != tt.expected {
+			t.Errorf("factor %d: expected %s; got %s", tt.factor, tt.expected, d)
+		}
+	}
+}
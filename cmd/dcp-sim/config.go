@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/zemirco/dcp"
+	"github.com/zemirco/dcp/block"
+)
+
+// config is the json description of a plant.
+type config struct {
+	Devices []deviceConfig `json:"devices"`
+}
+
+// deviceConfig describes a single device or, with count, a series of
+// devices. Their mac addresses, names and ip addresses are numbered
+// consecutively.
+type deviceConfig struct {
+	MAC      hardwareAddr `json:"mac"`
+	Count    int          `json:"count"`
+	Name     string       `json:"name"`
+	Alias    string       `json:"alias"`
+	Vendor   string       `json:"vendor"`
+	VendorID uint16       `json:"vendor_id"`
+	DeviceID uint16       `json:"device_id"`
+	IP       net.IP       `json:"ip"`
+	Subnet   net.IP       `json:"subnet"`
+	Gateway  net.IP       `json:"gateway"`
+	Role     []string     `json:"role"`
+	Quirks   []string     `json:"quirks"`
+}
+
+// device is a single emulated device.
+type device struct {
+	MAC         net.HardwareAddr
+	Profile     dcp.Profile
+	InOperation bool
+	IgnoreGet   bool
+}
+
+var roles = map[string]block.Role{
+	"device":      block.RoleIODevice,
+	"controller":  block.RoleIOController,
+	"multidevice": block.RoleIOMultidevice,
+	"supervisor":  block.RolePNSupervisor,
+}
+
+var quirks = map[string]func(d *device){
+	"in-operation": func(d *device) { d.InOperation = true },
+	"ignore-get":   func(d *device) { d.IgnoreGet = true },
+}
+
+// hardwareAddr is a mac address in its text form.
+type hardwareAddr net.HardwareAddr
+
+// UnmarshalText parses a mac address.
+func (h *hardwareAddr) UnmarshalText(b []byte) error {
+	mac, err := net.ParseMAC(string(b))
+	if err != nil {
+		return err
+	}
+	*h = hardwareAddr(mac)
+	return nil
+}
+
+// load reads the json description and returns all devices.
+func load(r io.Reader) ([]device, error) {
+	var c config
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return nil, err
+	}
+
+	var devices []device
+	seen := make(map[string]bool)
+	for i, dc := range c.Devices {
+		ds, err := dc.expand()
+		if err != nil {
+			return nil, fmt.Errorf("device %d: %v", i, err)
+		}
+		for _, d := range ds {
+			if seen[d.MAC.String()] {
+				return nil, fmt.Errorf("device %d: duplicate mac address %s", i, d.MAC)
+			}
+			seen[d.MAC.String()] = true
+		}
+		devices = append(devices, ds...)
+	}
+	return devices, nil
+}
+
+// expand returns the devices of a device description.
+func (dc deviceConfig) expand() ([]device, error) {
+	if len(dc.MAC) != 6 {
+		return nil, fmt.Errorf("invalid mac address %v", net.HardwareAddr(dc.MAC))
+	}
+
+	count := dc.Count
+	if count == 0 {
+		count = 1
+	}
+	if count < 0 || count > 1<<16 {
+		return nil, fmt.Errorf("invalid count %d", dc.Count)
+	}
+	if count > 1 && dc.Alias != "" {
+		return nil, fmt.Errorf("alias needs count 1")
+	}
+
+	var role block.Role
+	for _, name := range dc.Role {
+		r, ok := roles[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown role %q", name)
+		}
+		role |= r
+	}
+	if role == 0 {
+		role = block.RoleIODevice
+	}
+
+	for _, name := range dc.Quirks {
+		if _, ok := quirks[name]; !ok {
+			return nil, fmt.Errorf("unknown quirk %q", name)
+		}
+	}
+
+	devices := make([]device, count)
+	for i := range devices {
+		d := device{
+			MAC: addMAC(net.HardwareAddr(dc.MAC), i),
+			Profile: dcp.Profile{
+				NameOfStation:     dc.Name,
+				AliasName:         dc.Alias,
+				DeviceVendorValue: dc.Vendor,
+				VendorID:          dc.VendorID,
+				DeviceID:          dc.DeviceID,
+				Role:              role,
+				IPAddress:         addIP(dc.IP, i),
+				Subnetmask:        dc.Subnet,
+				StandardGateway:   dc.Gateway,
+			},
+		}
+		if count > 1 && dc.Name != "" {
+			d.Profile.NameOfStation = fmt.Sprintf("%s-%d", dc.Name, i+1)
+		}
+		for _, name := range dc.Quirks {
+			quirks[name](&d)
+		}
+		devices[i] = d
+	}
+	return devices, nil
+}
+
+// addMAC returns mac plus n.
+func addMAC(mac net.HardwareAddr, n int) net.HardwareAddr {
+	b := make([]byte, 8)
+	copy(b[2:], mac)
+	v := binary.BigEndian.Uint64(b) + uint64(n)
+	binary.BigEndian.PutUint64(b, v)
+	return net.HardwareAddr(b[2:])
+}
+
+// addIP returns ip plus n or nil if ip is nil.
+func addIP(ip net.IP, n int) net.IP {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return nil
+	}
+	b := make(net.IP, 4)
+	binary.BigEndian.PutUint32(b, binary.BigEndian.Uint32(ip4)+uint32(n))
+	return b
+}
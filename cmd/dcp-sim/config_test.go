@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zemirco/dcp/block"
+)
+
+func TestLoad(t *testing.T) {
+	f, err := os.Open("plant.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	devices, err := load(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 212 {
+		t.Fatalf("expected %d; got %d", 212, len(devices))
+	}
+
+	if devices[0].Profile.Role != block.RoleIOController {
+		t.Errorf("expected %d; got %d", block.RoleIOController, devices[0].Profile.Role)
+	}
+
+	io := devices[200]
+	if io.MAC.String() != "00:09:e5:00:01:c7" {
+		t.Errorf("expected %s; got %s", "00:09:e5:00:01:c7", io.MAC)
+	}
+	if io.Profile.NameOfStation != "io-200" {
+		t.Errorf("expected %s; got %s", "io-200", io.Profile.NameOfStation)
+	}
+	if io.Profile.IPAddress.String() != "192.168.0.209" {
+		t.Errorf("expected %s; got %s", "192.168.0.209", io.Profile.IPAddress)
+	}
+	if io.Profile.Role != block.RoleIODevice {
+		t.Errorf("expected %d; got %d", block.RoleIODevice, io.Profile.Role)
+	}
+
+	drive := devices[201]
+	if !drive.InOperation || !drive.IgnoreGet {
+		t.Errorf("expected quirks; got %+v", drive)
+	}
+
+	unnamed := devices[202]
+	if unnamed.Profile.NameOfStation != "" || unnamed.Profile.IPAddress != nil {
+		t.Errorf("expected no name and no ip address; got %+v", unnamed.Profile)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		err  string
+	}{
+		{
+			name: "invalid mac",
+			json: `{"devices": [{"mac": "00:09"}]}`,
+			err:  "invalid MAC address",
+		},
+		{
+			name: "unknown role",
+			json: `{"devices": [{"mac": "00:09:e5:00:00:01", "role": ["robot"]}]}`,
+			err:  `device 0: unknown role "robot"`,
+		},
+		{
+			name: "unknown quirk",
+			json: `{"devices": [{"mac": "00:09:e5:00:00:01", "quirks": ["crash"]}]}`,
+			err:  `device 0: unknown quirk "crash"`,
+		},
+		{
+			name: "duplicate mac",
+			json: `{"devices": [{"mac": "00:09:e5:00:00:01", "count": 2}, {"mac": "00:09:e5:00:00:02"}]}`,
+			err:  "device 1: duplicate mac address 00:09:e5:00:00:02",
+		},
+		{
+			name: "alias with count",
+			json: `{"devices": [{"mac": "00:09:e5:00:00:01", "count": 2, "alias": "port-001.switch"}]}`,
+			err:  "device 0: alias needs count 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(strings.NewReader(tt.json))
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected %s; got %v", tt.err, err)
+			}
+		})
+	}
+}
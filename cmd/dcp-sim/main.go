@@ -0,0 +1,171 @@
+// Command dcp-sim emulates a plant of devices described in a json file.
+//
+// Without an interface the devices live on an in memory segment and a
+// client identifies them periodically, which is useful for load tests.
+// With an interface, a real one or a tap device, the devices answer requests
+// from the network. Put the interface into promiscuous mode so the devices
+// receive frames sent to their own mac addresses.
+//
+//	dcp-sim -devices plant.json
+//	sudo ip link set dev eth0 promisc on
+//	sudo dcp-sim -devices plant.json -i eth0
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/zemirco/dcp"
+	"github.com/zemirco/dcp/hub"
+)
+
+func main() {
+	path := flag.String("devices", "plant.json", "json file describing the devices")
+	ifname := flag.String("i", "", "interface to serve on, in memory segment if empty")
+	latency := flag.Duration("latency", 0, "latency of the in memory segment")
+	jitter := flag.Duration("jitter", 0, "jitter of the in memory segment")
+	loss := flag.Float64("loss", 0, "packet loss of the in memory segment from 0 to 1")
+	seed := flag.Int64("seed", 1, "seed for jitter and packet loss")
+	interval := flag.Duration("identify", 5*time.Second, "identify interval on the in memory segment")
+	delay := flag.Uint("delay", 255, "response delay factor of identify requests")
+	flag.Parse()
+
+	f, err := os.Open(*path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	devices, err := load(f)
+	f.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	h := hub.New(&hub.Options{
+		Latency: *latency,
+		Jitter:  *jitter,
+		Loss:    *loss,
+		Seed:    *seed,
+	})
+
+	for _, d := range devices {
+		e, err := h.Endpoint(d.MAC)
+		if err != nil {
+			log.Fatal(err)
+		}
+		r := dcp.NewResponder(dcp.NewConn(e), d.Profile)
+		r.SetInOperation(d.InOperation)
+		r.SetIgnoreGet(d.IgnoreGet)
+		go r.Serve(ctx)
+	}
+	log.Printf("emulating %d devices", len(devices))
+
+	if *ifname != "" {
+		err = bridge(ctx, h, *ifname, devices)
+	} else {
+		err = monitor(ctx, h, *interval, uint16(*delay))
+	}
+	if err != nil && ctx.Err() == nil {
+		log.Fatal(err)
+	}
+}
+
+// bridge forwards frames between the interface and the in memory segment
+// until the context is canceled.
+func bridge(ctx context.Context, h *hub.Hub, ifname string, devices []device) error {
+	conn, err := dcp.Listen(ifname)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	uplink, err := h.Promiscuous(conn.HardwareAddr())
+	if err != nil {
+		return err
+	}
+	up := dcp.NewConn(uplink)
+	defer up.Close()
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+		up.Close()
+	}()
+
+	virtual := make(map[string]bool)
+	for _, d := range devices {
+		virtual[d.MAC.String()] = true
+	}
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- forward(conn, up, func(f *dcp.Frame) bool {
+			// the raw socket also sees the frames sent by the devices
+			return !virtual[f.Source.String()]
+		})
+	}()
+	go func() {
+		errs <- forward(up, conn, func(f *dcp.Frame) bool {
+			return true
+		})
+	}()
+
+	log.Printf("serving on %s", ifname)
+	return <-errs
+}
+
+// forward copies the frames accepted by keep from one connection to the
+// other. Malformed frames are skipped.
+func forward(from, to *dcp.Conn, keep func(f *dcp.Frame) bool) error {
+	for {
+		f, err := from.ReadFrame()
+		if err != nil {
+			if errors.Is(err, os.ErrClosed) || errors.Is(err, hub.ErrClosed) {
+				return err
+			}
+			continue
+		}
+		if !keep(f) {
+			continue
+		}
+		if err := to.WriteFrame(f); err != nil {
+			log.Printf("forward: %v", err)
+		}
+	}
+}
+
+// monitor identifies all devices on the in memory segment every interval
+// until the context is canceled.
+func monitor(ctx context.Context, h *hub.Hub, interval time.Duration, delay uint16) error {
+	e, err := h.Endpoint(net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01})
+	if err != nil {
+		return err
+	}
+	c := dcp.NewClient(dcp.NewConn(e))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		start := time.Now()
+		devices, err := c.Identify(ctx, &dcp.IdentifyOptions{ResponseDelay: delay})
+		if err != nil {
+			return err
+		}
+		log.Printf("identified %d devices in %s", len(devices), time.Since(start).Round(time.Millisecond))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package dcp
+
+import (
+	"net"
+	"time"
+)
+
+// maxFrameSize is the largest ethernet II frame without frame check sequence.
+const maxFrameSize = 1514
+
+// Transport sends and receives raw ethernet frames.
+type Transport interface {
+	// ReadPacket reads a single ethernet frame into b.
+	ReadPacket(b []byte) (int, error)
+	// WritePacket writes a single ethernet frame.
+	WritePacket(b []byte) error
+	// SetReadDeadline sets the deadline for future ReadPacket calls.
+	SetReadDeadline(t time.Time) error
+	// HardwareAddr returns the local hardware address.
+	HardwareAddr() net.HardwareAddr
+	// Close closes the transport.
+	Close() error
+}
+
+// Conn sends and receives frames over a transport.
+type Conn struct {
+	// Tracer receives the events of this connection. The package level
+	// tracer is used if it is nil. Set it before using the connection.
+	Tracer Tracer
+
+	transport Transport
+}
+
+// NewConn returns a new connection using the given transport.
+func NewConn(t Transport) *Conn {
+	return &Conn{
+		transport: t,
+	}
+}
+
+// HardwareAddr returns the local hardware address.
+func (c *Conn) HardwareAddr() net.HardwareAddr {
+	return c.transport.HardwareAddr()
+}
+
+// WriteFrame encodes and sends a single frame.
+func (c *Conn) WriteFrame(f *Frame) error {
+	trace := c.tracer()
+
+	b, err := f.marshalBinary(trace)
+	if err == nil {
+		err = c.transport.WritePacket(b)
+	}
+	trace.trace(Event{
+		Kind:  FrameWritten,
+		Frame: f,
+		Err:   err,
+	})
+	return err
+}
+
+// ReadFrame reads and decodes a single frame.
+func (c *Conn) ReadFrame() (*Frame, error) {
+	b, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+	return c.decode(b)
+}
+
+// decode decodes a single frame.
+func (c *Conn) decode(b []byte) (*Frame, error) {
+	trace := c.tracer()
+
+	f := &Frame{}
+	err := f.unmarshalBinary(b, trace)
+	trace.trace(Event{
+		Kind:  FrameRead,
+		Frame: f,
+		Err:   err,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// read reads a single undecoded frame.
+func (c *Conn) read() ([]byte, error) {
+	b := make([]byte, maxFrameSize)
+	n, err := c.transport.ReadPacket(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+
+// SetReadDeadline sets the deadline for future ReadFrame calls.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.transport.SetReadDeadline(t)
+}
+
+// Close closes the connection and its transport.
+func (c *Conn) Close() error {
+	return c.transport.Close()
+}
+
+func (c *Conn) tracer() Tracer {
+	if c.Tracer != nil {
+		return c.Tracer
+	}
+	return defaultTracer()
+}
@@ -0,0 +1,144 @@
+package dcp
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+// fakeTransport is an in memory transport for testing.
+type fakeTransport struct {
+	addr net.HardwareAddr
+	in   chan []byte
+	out  chan []byte
+	wake chan struct{}
+
+	mu       sync.Mutex
+	deadline time.Time
+}
+
+func newFakeTransport(addr net.HardwareAddr) *fakeTransport {
+	return &fakeTransport{
+		addr: addr,
+		in:   make(chan []byte, 16),
+		out:  make(chan []byte, 16),
+		wake: make(chan struct{}, 1),
+	}
+}
+
+func (f *fakeTransport) ReadPacket(b []byte) (int, error) {
+	for {
+		f.mu.Lock()
+		deadline := f.deadline
+		f.mu.Unlock()
+
+		var timeout <-chan time.Time
+		if !deadline.IsZero() {
+			if !time.Now().Before(deadline) {
+				return 0, timeoutError{}
+			}
+			timeout = time.After(time.Until(deadline))
+		}
+
+		select {
+		case p := <-f.in:
+			return copy(b, p), nil
+		case <-timeout:
+			return 0, timeoutError{}
+		case <-f.wake:
+		}
+	}
+}
+
+func (f *fakeTransport) WritePacket(b []byte) error {
+	f.out <- append([]byte(nil), b...)
+	return nil
+}
+
+func (f *fakeTransport) SetReadDeadline(t time.Time) error {
+	f.mu.Lock()
+	f.deadline = t
+	f.mu.Unlock()
+	select {
+	case f.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (f *fakeTransport) HardwareAddr() net.HardwareAddr {
+	return f.addr
+}
+
+func (f *fakeTransport) Close() error {
+	return nil
+}
+
+func TestConnWriteFrame(t *testing.T) {
+	source := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+	ft := newFakeTransport(source)
+	c := NewConn(ft)
+
+	request := NewIdentifyRequest(c.HardwareAddr())
+	request.XID = 0x01020304
+	if err := c.WriteFrame(request); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{
+		0x01, 0x0e, 0xcf, 0x00, 0x00, 0x00, 0xa4, 0x4c,
+		0xc8, 0xe5, 0x47, 0x21, 0x88, 0x92, 0xfe, 0xfe,
+		0x05, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0xff,
+		0x00, 0x04, 0xff, 0xff, 0x00, 0x00,
+	}
+	if diff := cmp.Diff(<-ft.out, expected); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestConnReadFrame(t *testing.T) {
+	ft := newFakeTransport(net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21})
+	c := NewConn(ft)
+
+	ft.in <- []byte{
+		0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21, 0x00, 0x09,
+		0xe5, 0x00, 0x9a, 0x20, 0x88, 0x92, 0xfe, 0xfd,
+		0x04, 0x01, 0x01, 0x02, 0x03, 0x04, 0x00, 0x00,
+		0x00, 0x08, 0x05, 0x04, 0x00, 0x03, 0x01, 0x02,
+		0x00, 0x00,
+	}
+
+	f, err := c.ReadFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Source.String() != "00:09:e5:00:9a:20" {
+		t.Errorf("expected %s; got %s", "00:09:e5:00:9a:20", f.Source)
+	}
+	if f.XID != 0x01020304 {
+		t.Errorf("expected %d; got %d", 0x01020304, f.XID)
+	}
+	if f.ServiceID != Set {
+		t.Errorf("expected %d; got %d", Set, f.ServiceID)
+	}
+}
+
+func TestConnReadFrameDeadline(t *testing.T) {
+	ft := newFakeTransport(nil)
+	c := NewConn(ft)
+
+	if err := c.SetReadDeadline(time.Now().Add(10 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ReadFrame(); !isTimeout(err) {
+		t.Errorf("expected timeout; got %v", err)
+	}
+}
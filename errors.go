@@ -0,0 +1,55 @@
+package dcp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zemirco/dcp/block"
+)
+
+var (
+	// ErrTruncated is returned when a frame is shorter than its headers or
+	// block lengths claim. It is the same error as block.ErrTruncated, so
+	// errors.Is matches truncated frames and truncated blocks alike.
+	ErrTruncated = block.ErrTruncated
+
+	// ErrPadding is returned when the padding byte after an odd length
+	// block is not zero.
+	ErrPadding = errors.New("dcp: invalid padding")
+
+	// ErrNoResponse is returned when a device does not answer a request in
+	// time.
+	ErrNoResponse = errors.New("dcp: no response")
+
+	// ErrBlockMissing is returned when a get response contains neither the
+	// requested block nor a control response for it.
+	ErrBlockMissing = errors.New("dcp: block missing in response")
+
+	// ErrNotConfirmed is returned when a reset is not confirmed with the
+	// name of station of the device.
+	ErrNotConfirmed = errors.New("dcp: reset not confirmed")
+
+	// ErrNoControlResponse is returned when a set response does not contain
+	// a control response for a block of the request.
+	ErrNoControlResponse = errors.New("dcp: no control response")
+)
+
+// FrameIDError is returned for telegrams with an unknown frame id.
+type FrameIDError struct {
+	FrameID FrameID
+}
+
+func (e *FrameIDError) Error() string {
+	return fmt.Sprintf("dcp: unknown frame id %#04x", uint16(e.FrameID))
+}
+
+// LengthError is returned when the DCP data length does not fit into the
+// telegram.
+type LengthError struct {
+	DCPDataLength uint16
+	Available     int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("dcp: data length %d exceeds %d available bytes", e.DCPDataLength, e.Available)
+}
@@ -38,6 +38,9 @@ func (e *EthernetII) MarshalBinary() ([]byte, error) {
 
 // UnmarshalBinary unmarshals a byte slice into a EthernetII.
 func (e *EthernetII) UnmarshalBinary(b []byte) error {
+	if len(b) < e.Len() {
+		return ErrTruncated
+	}
 
 	e.Destination = b[0:6]
 	e.Source = b[6:12]
@@ -51,3 +51,11 @@ func TestEthernetLen(t *testing.T) {
 		t.Errorf("expected %d; got %d", 14, e.Len())
 	}
 }
+
+func TestEthernetUnmarshalBinaryTruncated(t *testing.T) {
+	b := []byte{0x01, 0x0e, 0xcf, 0x00, 0x00, 0x00, 0xa4, 0x4c}
+	var e EthernetII
+	if err := e.UnmarshalBinary(b); err != ErrTruncated {
+		t.Errorf("expected %v; got %v", ErrTruncated, err)
+	}
+}
@@ -0,0 +1,7 @@
+package dcp
+
+// Telegrams of the internal tests for the tests of package dcp_test.
+var (
+	IdentifyResponseTelegram = identifyResponseTelegram
+	UnknownBlocksTelegram    = unknownBlocksTelegram
+)
@@ -15,8 +15,12 @@ const (
 	IdentifyRequest  FrameID = 0xfefe
 	IdentifyResponse FrameID = 0xfeff
 	GetSet           FrameID = 0xfefd
+	HelloRequest     FrameID = 0xfefc
 )
 
+// identifyMulticast is the destination of identify requests.
+var identifyMulticast = net.HardwareAddr{0x01, 0x0e, 0xcf, 0x00, 0x00, 0x00}
+
 // Frame is a single frame.
 type Frame struct {
 	EthernetII
@@ -25,51 +29,114 @@ type Frame struct {
 
 var _ block.Block = &Frame{}
 
-// NewIdentifyRequest returns an identify request.
-func NewIdentifyRequest(source net.HardwareAddr) *Frame {
+// NewIdentifyRequest returns an identify request. Without filter blocks
+// every device answers. Otherwise only devices matching all filter blocks
+// answer.
+func NewIdentifyRequest(source net.HardwareAddr, filter ...block.Block) *Frame {
+	blocks := filter
+	if len(blocks) == 0 {
+		blocks = []block.Block{block.NewAll()}
+	}
 
-	b := block.NewAll()
+	t := Telegram{
+		FrameID:       IdentifyRequest,
+		ServiceID:     Identify,
+		ServiceType:   Request,
+		XID:           rand.Uint32(),
+		ResponseDelay: 255,
+		Blocks:        blocks,
+	}
+	t.DCPDataLength = uint16(t.dataLen())
 
 	return &Frame{
 		EthernetII: EthernetII{
-			Destination: []byte{0x01, 0x0e, 0xcf, 0x00, 0x00, 0x00},
+			Destination: identifyMulticast,
 			Source:      source,
 			EtherType:   0x8892,
 		},
-		Telegram: Telegram{
-			FrameID:       IdentifyRequest,
-			ServiceID:     Identify,
-			ServiceType:   Request,
-			XID:           rand.Uint32(),
-			ResponseDelay: 255,
-			DCPDataLength: uint16(b.Len()),
-			All:           b,
-		},
+		Telegram: t,
 	}
 }
 
 // NewSetIPParameterRequest returns a set request.
 func NewSetIPParameterRequest(dst, src net.HardwareAddr, b *block.IPParameter) *Frame {
+	return newRequest(dst, src, Set, b)
+}
+
+// NewSetFullIPSuiteRequest returns a set request.
+func NewSetFullIPSuiteRequest(dst, src net.HardwareAddr, b *block.FullIPSuite) *Frame {
+	return newRequest(dst, src, Set, b)
+}
+
+// NewSetNameOfStationRequest returns a set request.
+func NewSetNameOfStationRequest(dst, src net.HardwareAddr, b *block.NameOfStation) *Frame {
+	return newRequest(dst, src, Set, b)
+}
+
+// NewSignalRequest returns a set request that lets the device flash its
+// signal LED.
+func NewSignalRequest(dst, src net.HardwareAddr) *Frame {
+	return newRequest(dst, src, Set, block.NewSignal())
+}
+
+// NewFactoryResetRequest returns a set request that resets the device to its
+// factory settings.
+func NewFactoryResetRequest(dst, src net.HardwareAddr) *Frame {
+	return newRequest(dst, src, Set, block.NewFactoryReset())
+}
+
+// NewResetToFactoryRequest returns a set request that resets the data
+// selected by mode to its factory values.
+func NewResetToFactoryRequest(dst, src net.HardwareAddr, mode block.ResetMode) *Frame {
+	return newRequest(dst, src, Set, block.NewResetToFactory(mode))
+}
+
+// NewSetTransaction returns a set request that wraps the blocks in a start
+// and a stop control block. The device applies all blocks together.
+func NewSetTransaction(dst, src net.HardwareAddr, blocks ...block.Block) *Frame {
+	all := make([]block.Block, 0, len(blocks)+2)
+	all = append(all, block.NewStart())
+	all = append(all, blocks...)
+	all = append(all, block.NewStop())
+	return newRequest(dst, src, Set, all...)
+}
+
+// NewGetRequest returns a get request for the selected blocks.
+func NewGetRequest(dst, src net.HardwareAddr, selectors []BlockSelector) *Frame {
+	blocks := make([]block.Block, len(selectors))
+	for i := range selectors {
+		blocks[i] = &selectors[i]
+	}
+	return newRequest(dst, src, Get, blocks...)
+}
+
+func newRequest(dst, src net.HardwareAddr, service ServiceID, blocks ...block.Block) *Frame {
+	t := Telegram{
+		FrameID:       GetSet,
+		ServiceID:     service,
+		ServiceType:   Request,
+		XID:           rand.Uint32(),
+		ResponseDelay: 255,
+		Blocks:        blocks,
+	}
+	t.DCPDataLength = uint16(t.dataLen())
+
 	return &Frame{
 		EthernetII: EthernetII{
 			Destination: dst,
 			Source:      src,
 			EtherType:   0x8892,
 		},
-		Telegram: Telegram{
-			FrameID:       GetSet,
-			ServiceID:     Set,
-			ServiceType:   Request,
-			XID:           rand.Uint32(),
-			ResponseDelay: 255,
-			DCPDataLength: uint16(b.Len()),
-			IPParameter:   b,
-		},
+		Telegram: t,
 	}
 }
 
 // MarshalBinary converts struct into byte slice.
 func (f *Frame) MarshalBinary() ([]byte, error) {
+	return f.marshalBinary(defaultTracer())
+}
+
+func (f *Frame) marshalBinary(trace Tracer) ([]byte, error) {
 	b := make([]byte, f.Len())
 	i := 0
 
@@ -80,7 +147,7 @@ func (f *Frame) MarshalBinary() ([]byte, error) {
 	copy(b, ethernetIIBytes)
 	i += f.EthernetII.Len()
 
-	telegramBytes, err := f.Telegram.MarshalBinary()
+	telegramBytes, err := f.Telegram.marshalBinary(trace)
 	if err != nil {
 		return b, err
 	}
@@ -91,12 +158,15 @@ func (f *Frame) MarshalBinary() ([]byte, error) {
 
 // UnmarshalBinary unmarshals a byte slice into a EthernetII.
 func (f *Frame) UnmarshalBinary(b []byte) error {
+	return f.unmarshalBinary(b, defaultTracer())
+}
 
+func (f *Frame) unmarshalBinary(b []byte, trace Tracer) error {
 	if err := f.EthernetII.UnmarshalBinary(b); err != nil {
 		return err
 	}
 
-	return f.Telegram.UnmarshalBinary(b[f.EthernetII.Len():])
+	return f.Telegram.unmarshalBinary(b[f.EthernetII.Len():], trace)
 }
 
 // Len returns length for name of station block.
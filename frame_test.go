@@ -0,0 +1,239 @@
+package dcp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zemirco/dcp/block"
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+func TestNewIdentifyRequest(t *testing.T) {
+	source := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+
+	f := NewIdentifyRequest(source)
+	f.XID = 0x01020304
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		0x01, 0x0e, 0xcf, 0x00, 0x00, 0x00, 0xa4, 0x4c,
+		0xc8, 0xe5, 0x47, 0x21, 0x88, 0x92, 0xfe, 0xfe,
+		0x05, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0xff,
+		0x00, 0x04, 0xff, 0xff, 0x00, 0x00,
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestNewIdentifyRequestFilter(t *testing.T) {
+	source := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+
+	f := NewIdentifyRequest(source,
+		block.NewNameOfStationFilter("zeiss"),
+		block.NewDeviceIDFilter(0x002a, 0x0a01),
+	)
+	f.XID = 0x01020304
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		0x01, 0x0e, 0xcf, 0x00, 0x00, 0x00, 0xa4, 0x4c,
+		0xc8, 0xe5, 0x47, 0x21, 0x88, 0x92, 0xfe, 0xfe,
+		0x05, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0xff,
+		0x00, 0x12, 0x02, 0x02, 0x00, 0x05, 0x7a, 0x65,
+		0x69, 0x73, 0x73, 0x00, 0x02, 0x03, 0x00, 0x04,
+		0x00, 0x2a, 0x0a, 0x01,
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+	if f.All() != nil {
+		t.Errorf("expected no all block; got %v", f.All())
+	}
+}
+
+// vendorBlock is a block type unknown to package block.
+type vendorBlock []byte
+
+func (v vendorBlock) Len() int                       { return len(v) }
+func (v vendorBlock) MarshalBinary() ([]byte, error) { return v, nil }
+func (v vendorBlock) UnmarshalBinary(b []byte) error { return nil }
+
+func TestNewIdentifyRequestFilterAll(t *testing.T) {
+	source := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+
+	f := NewIdentifyRequest(source,
+		block.NewAliasNameFilter("p.s"),
+		block.NewOEMDeviceIDFilter(0x011e, 0x0a01),
+		block.NewIPParameterFilter(net.IP{0xac, 0x13, 0x68, 0x05}, net.IP{0xff, 0xff, 0x00, 0x00}, net.IP{0x00, 0x00, 0x00, 0x00}),
+		block.NewDeviceInstanceFilter(0x00, 0x64),
+		vendorBlock{0x80, 0x01, 0x00, 0x02, 0xca, 0xfe},
+	)
+	f.XID = 0x01020304
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		0x01, 0x0e, 0xcf, 0x00, 0x00, 0x00, 0xa4, 0x4c,
+		0xc8, 0xe5, 0x47, 0x21, 0x88, 0x92, 0xfe, 0xfe,
+		0x05, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0xff,
+		0x00, 0x2c, 0x02, 0x06, 0x00, 0x03, 0x70, 0x2e,
+		0x73, 0x00, 0x02, 0x08, 0x00, 0x04, 0x01, 0x1e,
+		0x0a, 0x01, 0x01, 0x02, 0x00, 0x0c, 0xac, 0x13,
+		0x68, 0x05, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x02, 0x07, 0x00, 0x02, 0x00, 0x64,
+		0x80, 0x01, 0x00, 0x02, 0xca, 0xfe,
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestNewSetTransaction(t *testing.T) {
+	dst := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+	src := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+
+	ip := block.NewIPParameterQualifier()
+	ip.Qualifier = block.QualifierPermanent
+	ip.IPAddress = []byte{0xac, 0x13, 0x68, 0x05}
+	ip.Subnetmask = []byte{0xff, 0xff, 0x00, 0x00}
+	ip.StandardGateway = []byte{0x00, 0x00, 0x00, 0x00}
+
+	f := NewSetTransaction(dst, src,
+		block.NewNameOfStationQualifier(block.QualifierPermanent, "zeiss"),
+		ip,
+	)
+	f.XID = 0x01020304
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20, 0xa4, 0x4c,
+		0xc8, 0xe5, 0x47, 0x21, 0x88, 0x92, 0xfe, 0xfd,
+		0x04, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0xff,
+		0x00, 0x2a, 0x05, 0x01, 0x00, 0x02, 0x00, 0x00,
+		0x02, 0x02, 0x00, 0x07, 0x00, 0x01, 0x7a, 0x65,
+		0x69, 0x73, 0x73, 0x00, 0x01, 0x02, 0x00, 0x0e,
+		0x00, 0x01, 0xac, 0x13, 0x68, 0x05, 0xff, 0xff,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x05, 0x02,
+		0x00, 0x02, 0x00, 0x00,
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+
+	var decoded Frame
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Blocks) != 4 {
+		t.Fatalf("expected %d; got %d", 4, len(decoded.Blocks))
+	}
+	if _, ok := decoded.Blocks[0].(*block.Control); !ok {
+		t.Errorf("expected start block; got %T", decoded.Blocks[0])
+	}
+	reencoded, err := decoded.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(reencoded, expected); diff != "" {
+		t.Error(diff)
+	}
+}
+
+// TestNewSetTransactionDHCP switches a device to dhcp with its name of station
+// as client identifier. In the dhcp block of IEC 61158-6-10 with suboption 61
+// the DHCPParameterValue is only the client identifier type 0x00, which tells
+// the device to use its name of station.
+func TestNewSetTransactionDHCP(t *testing.T) {
+	dst := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+	src := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+
+	f := NewSetTransaction(dst, src,
+		block.NewDHCPClientIdentifierQualifier(block.QualifierPermanent, block.ClientIdentifierNameOfStation, nil),
+	)
+	f.XID = 0x01020304
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20, 0xa4, 0x4c,
+		0xc8, 0xe5, 0x47, 0x21, 0x88, 0x92, 0xfe, 0xfd,
+		0x04, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0xff,
+		0x00, 0x14, 0x05, 0x01, 0x00, 0x02, 0x00, 0x00,
+		0x03, 0x3d, 0x00, 0x03, 0x00, 0x01, 0x00, 0x00,
+		0x05, 0x02, 0x00, 0x02, 0x00, 0x00,
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+
+	var decoded Frame
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	d := decoded.DHCP(suboption.DHCPClientIdentifier)
+	if d == nil {
+		t.Fatal("expected client identifier block")
+	}
+	if d.Qualifier != block.QualifierPermanent {
+		t.Errorf("expected %d; got %d", block.QualifierPermanent, d.Qualifier)
+	}
+	typ, id, ok := d.ClientIdentifier()
+	if !ok {
+		t.Fatal("expected client identifier")
+	}
+	if typ != block.ClientIdentifierNameOfStation {
+		t.Errorf("expected %d; got %d", block.ClientIdentifierNameOfStation, typ)
+	}
+	if len(id) != 0 {
+		t.Errorf("expected no id; got %x", id)
+	}
+}
+
+func TestNewGetRequest(t *testing.T) {
+	dst := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+	src := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+
+	f := NewGetRequest(dst, src, []BlockSelector{
+		{Option: option.Properties, Suboption: suboption.NameOfStation},
+		{Option: option.IP, Suboption: suboption.IPParameter},
+	})
+	f.XID = 0x01020304
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20, 0xa4, 0x4c,
+		0xc8, 0xe5, 0x47, 0x21, 0x88, 0x92, 0xfe, 0xfd,
+		0x01, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0xff,
+		0x00, 0x04, 0x02, 0x02, 0x01, 0x02,
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+
+	var decoded Frame
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(decoded.Blocks, f.Blocks); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,163 @@
+package dcp_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zemirco/dcp"
+	"github.com/zemirco/dcp/pcap"
+)
+
+// seedFrames cover identify, get and set requests and responses. The packets
+// of the captures in testdata are added by seedCaptures.
+var seedFrames = [][]byte{
+	// identify request
+	{
+		0x01, 0x0e, 0xcf, 0x00, 0x00, 0x00, 0xa4, 0x4c,
+		0xc8, 0xe5, 0x47, 0x21, 0x88, 0x92, 0xfe, 0xfe,
+		0x05, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0xff,
+		0x00, 0x04, 0xff, 0xff, 0x00, 0x00,
+	},
+	// identify response
+	append([]byte{
+		0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21, 0x00, 0x09,
+		0xe5, 0x00, 0x9a, 0x20, 0x88, 0x92,
+	}, dcp.IdentifyResponseTelegram...),
+	// identify response with unknown blocks
+	append([]byte{
+		0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21, 0x00, 0x09,
+		0xe5, 0x00, 0x9a, 0x20, 0x88, 0x92,
+	}, dcp.UnknownBlocksTelegram...),
+	// get request for name of station and ip parameter
+	{
+		0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20, 0xa4, 0x4c,
+		0xc8, 0xe5, 0x47, 0x21, 0x88, 0x92, 0xfe, 0xfd,
+		0x01, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0x00,
+		0x00, 0x04, 0x02, 0x02, 0x01, 0x02,
+	},
+	// get response with name of station and ip parameter
+	{
+		0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21, 0x00, 0x09,
+		0xe5, 0x00, 0x9a, 0x20, 0x88, 0x92, 0xfe, 0xfd,
+		0x01, 0x01, 0x01, 0x02, 0x03, 0x04, 0x00, 0x00,
+		0x00, 0x1e, 0x02, 0x02, 0x00, 0x07, 0x00, 0x00,
+		0x7a, 0x65, 0x69, 0x73, 0x73, 0x00, 0x01, 0x02,
+		0x00, 0x0e, 0x00, 0x01, 0xac, 0x13, 0x68, 0x05,
+		0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	},
+	// set request with name of station and ip parameter
+	{
+		0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20, 0xa4, 0x4c,
+		0xc8, 0xe5, 0x47, 0x21, 0x88, 0x92, 0xfe, 0xfd,
+		0x04, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0x00,
+		0x00, 0x1e, 0x02, 0x02, 0x00, 0x07, 0x00, 0x01,
+		0x7a, 0x65, 0x69, 0x73, 0x73, 0x00, 0x01, 0x02,
+		0x00, 0x0e, 0x00, 0x01, 0xac, 0x13, 0x68, 0x05,
+		0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	},
+	// set response
+	{
+		0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21, 0x00, 0x09,
+		0xe5, 0x00, 0x9a, 0x20, 0x88, 0x92, 0xfe, 0xfd,
+		0x04, 0x01, 0x01, 0x02, 0x03, 0x04, 0x00, 0x00,
+		0x00, 0x08, 0x05, 0x04, 0x00, 0x03, 0x01, 0x02,
+		0x00, 0x00,
+	},
+}
+
+// seedCaptures returns the ethernet packets of all pcap and pcapng files in
+// testdata.
+func seedCaptures(f *testing.F) [][]byte {
+	names, err := filepath.Glob(filepath.Join("testdata", "*.pcap*"))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	var packets [][]byte
+	for _, name := range names {
+		file, err := os.Open(name)
+		if err != nil {
+			f.Fatal(err)
+		}
+		defer file.Close()
+
+		r, err := pcap.NewPacketReader(file)
+		if err != nil {
+			f.Fatalf("%s: %v", name, err)
+		}
+		for {
+			p, err := r.ReadPacket()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				f.Fatalf("%s: %v", name, err)
+			}
+			if p.LinkType == pcap.LinkTypeEthernet {
+				packets = append(packets, p.Data)
+			}
+		}
+	}
+	return packets
+}
+
+func FuzzFrameUnmarshalBinary(f *testing.F) {
+	for _, b := range append(seedFrames, seedCaptures(f)...) {
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var first dcp.Frame
+		if err := first.UnmarshalBinary(b); err != nil {
+			return
+		}
+		encoded, err := first.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var second dcp.Frame
+		if err := second.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("decoding encoded frame: %v", err)
+		}
+		reencoded, err := second.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, reencoded) {
+			t.Errorf("encoding not stable:\n%x\n%x", encoded, reencoded)
+		}
+	})
+}
+
+func FuzzTelegramUnmarshalBinary(f *testing.F) {
+	for _, b := range append(seedFrames, seedCaptures(f)...) {
+		if len(b) > 14 {
+			f.Add(b[14:])
+		}
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var first dcp.Telegram
+		if err := first.UnmarshalBinary(b); err != nil {
+			return
+		}
+		encoded, err := first.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var second dcp.Telegram
+		if err := second.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("decoding encoded telegram: %v", err)
+		}
+		reencoded, err := second.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, reencoded) {
+			t.Errorf("encoding not stable:\n%x\n%x", encoded, reencoded)
+		}
+	})
+}
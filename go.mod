@@ -1,6 +1,6 @@
 module github.com/zemirco/dcp
 
-go 1.12
+go 1.18
 
 require (
 	github.com/davecgh/go-spew v1.1.1
@@ -0,0 +1,298 @@
+// Package hub provides an in memory ethernet segment. Its endpoints are
+// transports for dcp.NewConn, so clients and responders can talk to each
+// other without raw sockets.
+package hub
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrAddrInUse is returned when an endpoint with the same hardware
+	// address is already attached to the hub.
+	ErrAddrInUse = errors.New("hub: hardware address in use")
+
+	// ErrClosed is returned when using a closed endpoint.
+	ErrClosed = errors.New("hub: endpoint closed")
+
+	// ErrShortFrame is returned when writing a frame without ethernet
+	// header.
+	ErrShortFrame = errors.New("hub: frame shorter than ethernet header")
+)
+
+// queueSize is the number of frames an endpoint buffers. Further frames are
+// dropped like a network card does when nobody reads.
+const queueSize = 256
+
+// Options configure the faults a hub injects.
+type Options struct {
+	// Latency delays every frame.
+	Latency time.Duration
+
+	// Jitter adds a random delay of up to Jitter to every frame. Frames
+	// overtake each other when jitter is larger than the time between them.
+	Jitter time.Duration
+
+	// Loss is the probability from 0 to 1 that a frame is dropped. It is
+	// applied to every receiver on its own.
+	Loss float64
+
+	// Seed seeds the random source for jitter and loss. The same seed and
+	// the same sequence of frames result in the same faults.
+	Seed int64
+}
+
+// Hub is an in memory ethernet segment. Multicast and broadcast frames are
+// delivered to all other endpoints, unicast frames only to the endpoint with
+// the destination address.
+type Hub struct {
+	opts Options
+
+	mu        sync.Mutex
+	rand      *rand.Rand
+	endpoints []*Endpoint
+}
+
+// New returns a new hub. Nil options mean a perfect segment.
+func New(opts *Options) *Hub {
+	if opts == nil {
+		opts = &Options{}
+	}
+	return &Hub{
+		opts: *opts,
+		rand: rand.New(rand.NewSource(opts.Seed)),
+	}
+}
+
+// Endpoint attaches a new endpoint with the given hardware address.
+func (h *Hub) Endpoint(addr net.HardwareAddr) (*Endpoint, error) {
+	return h.attach(addr, false)
+}
+
+// Promiscuous attaches a new endpoint that receives all frames regardless of
+// their destination. It is used to bridge the hub to a real network.
+func (h *Hub) Promiscuous(addr net.HardwareAddr) (*Endpoint, error) {
+	return h.attach(addr, true)
+}
+
+func (h *Hub) attach(addr net.HardwareAddr, promiscuous bool) (*Endpoint, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, e := range h.endpoints {
+		if e.addr.String() == addr.String() {
+			return nil, ErrAddrInUse
+		}
+	}
+
+	e := &Endpoint{
+		hub:         h,
+		addr:        append(net.HardwareAddr(nil), addr...),
+		promiscuous: promiscuous,
+		queue:       make(chan []byte, queueSize),
+		wake:        make(chan struct{}, 1),
+		line:        make(chan delayed, queueSize),
+		closed:      make(chan struct{}),
+	}
+	h.endpoints = append(h.endpoints, e)
+	go e.run()
+	return e, nil
+}
+
+// send delivers a frame from the source endpoint to all receivers.
+func (h *Hub) send(from *Endpoint, b []byte) {
+	dst := net.HardwareAddr(b[0:6])
+	multicast := dst[0]&0x01 != 0
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, e := range h.endpoints {
+		if e == from {
+			continue
+		}
+		if !multicast && !e.promiscuous && e.addr.String() != dst.String() {
+			continue
+		}
+		if h.opts.Loss > 0 && h.rand.Float64() < h.opts.Loss {
+			continue
+		}
+
+		delay := h.opts.Latency
+		if h.opts.Jitter > 0 {
+			delay += time.Duration(h.rand.Int63n(int64(h.opts.Jitter)))
+		}
+
+		p := append([]byte(nil), b...)
+		switch {
+		case delay == 0:
+			e.deliver(p)
+		case h.opts.Jitter == 0:
+			// keep the order of frames with a constant delay
+			select {
+			case e.line <- delayed{due: time.Now().Add(delay), p: p}:
+			default:
+			}
+		default:
+			e := e
+			time.AfterFunc(delay, func() {
+				e.deliver(p)
+			})
+		}
+	}
+}
+
+// remove detaches an endpoint.
+func (h *Hub) remove(e *Endpoint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i := range h.endpoints {
+		if h.endpoints[i] == e {
+			h.endpoints = append(h.endpoints[:i], h.endpoints[i+1:]...)
+			return
+		}
+	}
+}
+
+// Endpoint is a single station on a hub. It implements dcp.Transport.
+type Endpoint struct {
+	hub         *Hub
+	addr        net.HardwareAddr
+	promiscuous bool
+
+	queue  chan []byte
+	wake   chan struct{}
+	line   chan delayed
+	closed chan struct{}
+	once   sync.Once
+
+	mu       sync.Mutex
+	deadline time.Time
+}
+
+// delayed is a frame that is delivered at due.
+type delayed struct {
+	due time.Time
+	p   []byte
+}
+
+// run delivers delayed frames in order until the endpoint is closed.
+func (e *Endpoint) run() {
+	for {
+		select {
+		case <-e.closed:
+			return
+		case d := <-e.line:
+			t := time.NewTimer(time.Until(d.due))
+			select {
+			case <-e.closed:
+				t.Stop()
+				return
+			case <-t.C:
+				e.deliver(d.p)
+			}
+		}
+	}
+}
+
+// deliver queues a frame for reading.
+func (e *Endpoint) deliver(p []byte) {
+	select {
+	case <-e.closed:
+	case e.queue <- p:
+	default:
+	}
+}
+
+// ReadPacket reads a single ethernet frame into b.
+func (e *Endpoint) ReadPacket(b []byte) (int, error) {
+	for {
+		e.mu.Lock()
+		deadline := e.deadline
+		e.mu.Unlock()
+
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if !deadline.IsZero() {
+			d := time.Until(deadline)
+			if d <= 0 {
+				return 0, os.ErrDeadlineExceeded
+			}
+			timer = time.NewTimer(d)
+			timeout = timer.C
+		}
+
+		n, err := e.wait(b, timeout)
+		if timer != nil {
+			timer.Stop()
+		}
+		if err != errWake {
+			return n, err
+		}
+	}
+}
+
+// errWake is returned by wait when the deadline changed.
+var errWake = errors.New("hub: deadline changed")
+
+// wait waits for a frame, the timeout or a new deadline.
+func (e *Endpoint) wait(b []byte, timeout <-chan time.Time) (int, error) {
+	select {
+	case <-e.closed:
+		return 0, ErrClosed
+	case p := <-e.queue:
+		return copy(b, p), nil
+	case <-timeout:
+		return 0, os.ErrDeadlineExceeded
+	case <-e.wake:
+		return 0, errWake
+	}
+}
+
+// WritePacket sends a single ethernet frame to the hub.
+func (e *Endpoint) WritePacket(b []byte) error {
+	select {
+	case <-e.closed:
+		return ErrClosed
+	default:
+	}
+	if len(b) < 14 {
+		return ErrShortFrame
+	}
+	e.hub.send(e, b)
+	return nil
+}
+
+// SetReadDeadline sets the deadline for future ReadPacket calls. A zero
+// time means no deadline.
+func (e *Endpoint) SetReadDeadline(t time.Time) error {
+	e.mu.Lock()
+	e.deadline = t
+	e.mu.Unlock()
+
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// HardwareAddr returns the hardware address of the endpoint.
+func (e *Endpoint) HardwareAddr() net.HardwareAddr {
+	return e.addr
+}
+
+// Close detaches the endpoint from the hub. Pending reads return ErrClosed.
+func (e *Endpoint) Close() error {
+	e.once.Do(func() {
+		close(e.closed)
+		e.hub.remove(e)
+	})
+	return nil
+}
@@ -0,0 +1,216 @@
+package hub
+
+import (
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+)
+
+var (
+	mac1 = net.HardwareAddr{0x00, 0x00, 0x00, 0x00, 0x00, 0x01}
+	mac2 = net.HardwareAddr{0x00, 0x00, 0x00, 0x00, 0x00, 0x02}
+	mac3 = net.HardwareAddr{0x00, 0x00, 0x00, 0x00, 0x00, 0x03}
+
+	multicast = net.HardwareAddr{0x01, 0x0e, 0xcf, 0x00, 0x00, 0x00}
+)
+
+func frame(dst, src net.HardwareAddr, payload byte) []byte {
+	b := make([]byte, 15)
+	copy(b[0:6], dst)
+	copy(b[6:12], src)
+	b[12], b[13] = 0x88, 0x92
+	b[14] = payload
+	return b
+}
+
+func endpoints(t *testing.T, h *Hub, macs ...net.HardwareAddr) []*Endpoint {
+	var es []*Endpoint
+	for _, mac := range macs {
+		e, err := h.Endpoint(mac)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { e.Close() })
+		es = append(es, e)
+	}
+	return es
+}
+
+// read reads a single frame and returns its payload or -1 on timeout.
+func read(t *testing.T, e *Endpoint, timeout time.Duration) int {
+	if err := e.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 1514)
+	n, err := e.ReadPacket(b)
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return -1
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return int(b[n-1])
+}
+
+func TestHubUnicast(t *testing.T) {
+	es := endpoints(t, New(nil), mac1, mac2, mac3)
+
+	if err := es[0].WritePacket(frame(mac2, mac1, 7)); err != nil {
+		t.Fatal(err)
+	}
+	if p := read(t, es[1], 10*time.Millisecond); p != 7 {
+		t.Errorf("expected %d; got %d", 7, p)
+	}
+	if p := read(t, es[2], 10*time.Millisecond); p != -1 {
+		t.Errorf("expected %d; got %d", -1, p)
+	}
+}
+
+func TestHubMulticast(t *testing.T) {
+	es := endpoints(t, New(nil), mac1, mac2, mac3)
+
+	if err := es[0].WritePacket(frame(multicast, mac1, 7)); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range es[1:] {
+		if p := read(t, e, 10*time.Millisecond); p != 7 {
+			t.Errorf("expected %d; got %d", 7, p)
+		}
+	}
+	if p := read(t, es[0], 10*time.Millisecond); p != -1 {
+		t.Errorf("expected sender not to receive its own frame; got %d", p)
+	}
+}
+
+func TestHubPromiscuous(t *testing.T) {
+	h := New(nil)
+	es := endpoints(t, h, mac1, mac2)
+
+	p, err := h.Promiscuous(mac3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	if err := es[0].WritePacket(frame(mac2, mac1, 7)); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(t, p, 10*time.Millisecond); got != 7 {
+		t.Errorf("expected %d; got %d", 7, got)
+	}
+	if got := read(t, es[1], 10*time.Millisecond); got != 7 {
+		t.Errorf("expected %d; got %d", 7, got)
+	}
+}
+
+func TestHubLatency(t *testing.T) {
+	es := endpoints(t, New(&Options{Latency: 20 * time.Millisecond}), mac1, mac2)
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		if err := es[0].WritePacket(frame(mac2, mac1, byte(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		if p := read(t, es[1], time.Second); p != i {
+			t.Errorf("expected %d; got %d", i, p)
+		}
+	}
+	if d := time.Since(start); d < 20*time.Millisecond {
+		t.Errorf("expected at least %s; got %s", 20*time.Millisecond, d)
+	}
+}
+
+func TestHubJitter(t *testing.T) {
+	es := endpoints(t, New(&Options{Jitter: 10 * time.Millisecond, Seed: 1}), mac1, mac2)
+
+	for i := 0; i < 10; i++ {
+		if err := es[0].WritePacket(frame(mac2, mac1, byte(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	seen := make(map[int]bool)
+	for i := 0; i < 10; i++ {
+		seen[read(t, es[1], time.Second)] = true
+	}
+	for i := 0; i < 10; i++ {
+		if !seen[i] {
+			t.Errorf("expected frame %d", i)
+		}
+	}
+}
+
+func TestHubLoss(t *testing.T) {
+	received := func(seed int64) []int {
+		es := endpoints(t, New(&Options{Loss: 0.5, Seed: seed}), mac1, mac2)
+		for i := 0; i < 20; i++ {
+			if err := es[0].WritePacket(frame(mac2, mac1, byte(i))); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var ps []int
+		for {
+			p := read(t, es[1], 10*time.Millisecond)
+			if p == -1 {
+				return ps
+			}
+			ps = append(ps, p)
+		}
+	}
+
+	first := received(1)
+	if len(first) == 0 || len(first) == 20 {
+		t.Errorf("expected some frames to be lost; got %d of %d", len(first), 20)
+	}
+	second := received(1)
+	if len(first) != len(second) {
+		t.Fatalf("expected %d; got %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("expected %d; got %d", first[i], second[i])
+		}
+	}
+}
+
+func TestHubDeadline(t *testing.T) {
+	es := endpoints(t, New(nil), mac1)
+
+	if err := es[0].SetReadDeadline(time.Now().Add(10 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	_, err := es[0].ReadPacket(make([]byte, 1514))
+	timeout, ok := err.(interface{ Timeout() bool })
+	if !ok || !timeout.Timeout() {
+		t.Errorf("expected timeout; got %v", err)
+	}
+}
+
+func TestHubClose(t *testing.T) {
+	h := New(nil)
+	es := endpoints(t, h, mac1)
+
+	if _, err := h.Endpoint(mac1); err != ErrAddrInUse {
+		t.Errorf("expected %v; got %v", ErrAddrInUse, err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := es[0].ReadPacket(make([]byte, 1514))
+		errs <- err
+	}()
+	es[0].Close()
+	if err := <-errs; err != ErrClosed {
+		t.Errorf("expected %v; got %v", ErrClosed, err)
+	}
+	if err := es[0].WritePacket(frame(mac2, mac1, 0)); err != ErrClosed {
+		t.Errorf("expected %v; got %v", ErrClosed, err)
+	}
+
+	if _, err := h.Endpoint(mac1); err != nil {
+		t.Errorf("expected address to be free after close; got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+//go:build linux
+// +build linux
+
+package dcp
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// htons converts n from host to network byte order. It writes n in network
+// order and reads it back in host order, so it works on little and big endian
+// machines alike.
+func htons(n uint16) uint16 {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], n)
+	return *(*uint16)(unsafe.Pointer(&b[0]))
+}
+
+// packetConn is a transport based on a linux AF_PACKET socket.
+type packetConn struct {
+	file *os.File
+	addr net.HardwareAddr
+}
+
+var _ Transport = &packetConn{}
+
+// Listen opens a raw socket on the named interface.
+// It needs the CAP_NET_RAW capability.
+func Listen(ifname string) (*Conn, error) {
+	t, err := ListenTransport(ifname)
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(t), nil
+}
+
+// ListenTransport opens a raw socket on the named interface and returns it
+// as transport, e.g. to wrap it before creating a connection.
+// It needs the CAP_NET_RAW capability.
+func ListenTransport(ifname string) (Transport, error) {
+	ifi, err := net.InterfaceByName(ifname)
+	if err != nil {
+		return nil, err
+	}
+	return listenPacket(ifi)
+}
+
+func listenPacket(ifi *net.Interface) (*packetConn, error) {
+	protocol := htons(0x8892)
+
+	fd, err := syscall.Socket(syscall.AF_PACKET, syscall.SOCK_RAW|syscall.SOCK_NONBLOCK|syscall.SOCK_CLOEXEC, int(protocol))
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+
+	addr := syscall.SockaddrLinklayer{
+		Protocol: protocol,
+		Ifindex:  ifi.Index,
+	}
+	if err := syscall.Bind(fd, &addr); err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("bind", err)
+	}
+
+	// the socket is non blocking so the file is registered with the
+	// runtime poller and supports deadlines
+	return &packetConn{
+		file: os.NewFile(uintptr(fd), "packet:"+ifi.Name),
+		addr: ifi.HardwareAddr,
+	}, nil
+}
+
+// ReadPacket reads a single ethernet frame.
+func (p *packetConn) ReadPacket(b []byte) (int, error) {
+	return p.file.Read(b)
+}
+
+// WritePacket writes a single ethernet frame.
+func (p *packetConn) WritePacket(b []byte) error {
+	_, err := p.file.Write(b)
+	return err
+}
+
+// SetReadDeadline sets the read deadline.
+func (p *packetConn) SetReadDeadline(t time.Time) error {
+	return p.file.SetReadDeadline(t)
+}
+
+// HardwareAddr returns the hardware address of the interface.
+func (p *packetConn) HardwareAddr() net.HardwareAddr {
+	return p.addr
+}
+
+// Close closes the socket.
+func (p *packetConn) Close() error {
+	return p.file.Close()
+}
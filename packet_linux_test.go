@@ -0,0 +1,17 @@
+//go:build linux
+// +build linux
+
+package dcp
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestHtons(t *testing.T) {
+	n := htons(0x8892)
+	b := (*[2]byte)(unsafe.Pointer(&n))
+	if b[0] != 0x88 || b[1] != 0x92 {
+		t.Errorf("expected %x; got %x", []byte{0x88, 0x92}, b[:])
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+// +build !linux
+
+package dcp
+
+import (
+	"errors"
+	"runtime"
+)
+
+// Listen opens a raw socket on the named interface.
+// Raw sockets are only supported on linux.
+func Listen(ifname string) (*Conn, error) {
+	return nil, errors.New("dcp: raw sockets are not supported on " + runtime.GOOS)
+}
+
+// ListenTransport opens a raw socket on the named interface and returns it
+// as transport. Raw sockets are only supported on linux.
+func ListenTransport(ifname string) (Transport, error) {
+	return nil, errors.New("dcp: raw sockets are not supported on " + runtime.GOOS)
+}
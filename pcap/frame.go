@@ -0,0 +1,73 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/zemirco/dcp"
+)
+
+// etherTypes of frames.
+const (
+	etherTypeVLAN = 0x8100
+	etherTypeDCP  = 0x8892
+)
+
+// Frame is a dcp frame read from a capture.
+type Frame struct {
+	Timestamp time.Time
+	*dcp.Frame
+}
+
+// ReadFrames reads all packets of a capture and returns the dcp frames.
+// VLAN tags are removed. Other packets and malformed frames are skipped.
+func ReadFrames(r PacketReader) ([]Frame, error) {
+	var frames []Frame
+	for {
+		p, err := r.ReadPacket()
+		if err == io.EOF {
+			return frames, nil
+		}
+		if err != nil {
+			return frames, err
+		}
+
+		f, ok := Decode(p)
+		if !ok {
+			continue
+		}
+		frames = append(frames, Frame{
+			Timestamp: p.Timestamp,
+			Frame:     f,
+		})
+	}
+}
+
+// Decode decodes a single packet. It reports false if the packet is not a
+// well formed dcp frame.
+func Decode(p *Packet) (*dcp.Frame, bool) {
+	if p.LinkType != LinkTypeEthernet || len(p.Data) < 14 {
+		return nil, false
+	}
+
+	b := p.Data
+	if binary.BigEndian.Uint16(b[12:14]) == etherTypeVLAN {
+		if len(b) < 18 {
+			return nil, false
+		}
+		untagged := make([]byte, len(b)-4)
+		copy(untagged[0:12], b[0:12])
+		copy(untagged[12:], b[16:])
+		b = untagged
+	}
+	if binary.BigEndian.Uint16(b[12:14]) != etherTypeDCP {
+		return nil, false
+	}
+
+	f := &dcp.Frame{}
+	if err := f.UnmarshalBinary(b); err != nil {
+		return nil, false
+	}
+	return f, true
+}
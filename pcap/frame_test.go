@@ -0,0 +1,70 @@
+package pcap
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/zemirco/dcp"
+)
+
+var (
+	mac1 = net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+	mac2 = net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02}
+)
+
+func TestReadFrames(t *testing.T) {
+	request, err := dcp.NewSignalRequest(mac2, mac1).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tagged := make([]byte, len(request)+4)
+	copy(tagged[0:12], request[0:12])
+	copy(tagged[12:16], []byte{0x81, 0x00, 0x00, 0x05})
+	copy(tagged[16:], request[12:])
+
+	arp := make([]byte, 42)
+	copy(arp[12:14], []byte{0x08, 0x06})
+
+	truncated := request[:20]
+
+	var buf bytes.Buffer
+	w, err := NewNgWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range [][]byte{request, arp, tagged, truncated} {
+		p := &Packet{
+			Timestamp: time.Unix(int64(i), 0),
+			Data:      b,
+		}
+		if err := w.WritePacket(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := NewPacketReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames, err := ReadFrames(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 2 {
+		t.Fatalf("expected %d; got %d", 2, len(frames))
+	}
+	for i, f := range frames {
+		if f.ServiceID != dcp.Set {
+			t.Errorf("expected %v; got %v", dcp.Set, f.ServiceID)
+		}
+		if f.Source.String() != mac1.String() {
+			t.Errorf("expected %s; got %s", mac1, f.Source)
+		}
+		if sec := f.Timestamp.Unix(); sec != int64(2*i) {
+			t.Errorf("expected %d; got %d", 2*i, sec)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Package pcap reads and writes capture files in the pcap and pcapng formats,
+// e.g. to decode frames from Wireshark captures or to record the frames of a
+// connection.
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+// LinkTypeEthernet is the link type of ethernet captures.
+const LinkTypeEthernet = 1
+
+// maxPacketSize limits the size of a single packet so corrupt files cannot
+// allocate arbitrary memory.
+const maxPacketSize = 262144
+
+var (
+	// ErrFormat is returned for files that are neither pcap nor pcapng.
+	ErrFormat = errors.New("pcap: unknown file format")
+
+	// ErrInvalid is returned for malformed headers or blocks.
+	ErrInvalid = errors.New("pcap: invalid file")
+
+	// ErrLinkType is returned when writing a packet that is not an
+	// ethernet frame.
+	ErrLinkType = errors.New("pcap: link type not supported")
+)
+
+// Packet is a single captured packet.
+type Packet struct {
+	Timestamp time.Time
+
+	// Length is the length of the packet on the wire. It is larger than
+	// the data if the packet was truncated while capturing.
+	Length int
+
+	// LinkType is the link type of the interface the packet was captured
+	// on. Zero means ethernet when writing.
+	LinkType uint16
+
+	Data []byte
+}
+
+// PacketReader reads packets from a capture. ReadPacket returns io.EOF at
+// the end of the capture.
+type PacketReader interface {
+	ReadPacket() (*Packet, error)
+}
+
+// PacketWriter writes packets to a capture.
+type PacketWriter interface {
+	WritePacket(p *Packet) error
+}
+
+const (
+	magicMicroseconds = 0xa1b2c3d4
+	magicNanoseconds  = 0xa1b23c4d
+	magicSection      = 0x0a0d0d0a
+)
+
+// NewPacketReader returns a reader for pcap or pcapng files depending on the
+// format of r.
+func NewPacketReader(r io.Reader) (PacketReader, error) {
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, err
+	}
+	r = io.MultiReader(bytes.NewReader(magic), r)
+
+	switch {
+	case binary.LittleEndian.Uint32(magic) == magicSection:
+		return NewNgReader(r)
+	case binary.LittleEndian.Uint32(magic) == magicMicroseconds,
+		binary.LittleEndian.Uint32(magic) == magicNanoseconds,
+		binary.BigEndian.Uint32(magic) == magicMicroseconds,
+		binary.BigEndian.Uint32(magic) == magicNanoseconds:
+		return NewReader(r)
+	}
+	return nil, ErrFormat
+}
+
+// Reader reads pcap files.
+type Reader struct {
+	// LinkType is the link type of all packets.
+	LinkType uint16
+
+	r     io.Reader
+	order binary.ByteOrder
+	nano  bool
+}
+
+var _ PacketReader = &Reader{}
+
+// NewReader reads the file header and returns a new reader.
+func NewReader(r io.Reader) (*Reader, error) {
+	b := make([]byte, 24)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+
+	pr := &Reader{r: r}
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		switch order.Uint32(b[0:4]) {
+		case magicMicroseconds:
+			pr.order = order
+		case magicNanoseconds:
+			pr.order = order
+			pr.nano = true
+		}
+	}
+	if pr.order == nil {
+		return nil, ErrFormat
+	}
+
+	pr.LinkType = uint16(pr.order.Uint32(b[20:24]))
+	return pr, nil
+}
+
+// ReadPacket reads the next packet.
+func (r *Reader) ReadPacket() (*Packet, error) {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return nil, err
+	}
+
+	sec := int64(r.order.Uint32(b[0:4]))
+	frac := int64(r.order.Uint32(b[4:8]))
+	captured := r.order.Uint32(b[8:12])
+	length := r.order.Uint32(b[12:16])
+
+	if captured > maxPacketSize {
+		return nil, ErrInvalid
+	}
+
+	data := make([]byte, captured)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return nil, unexpected(err)
+	}
+
+	if !r.nano {
+		frac *= 1000
+	}
+	return &Packet{
+		Timestamp: time.Unix(sec, frac),
+		Length:    int(length),
+		LinkType:  r.LinkType,
+		Data:      data,
+	}, nil
+}
+
+// Writer writes pcap files of ethernet frames with a resolution of
+// nanoseconds. Every packet is a single write to the underlying writer.
+type Writer struct {
+	w io.Writer
+}
+
+var _ PacketWriter = &Writer{}
+
+// NewWriter writes the file header and returns a new writer.
+func NewWriter(w io.Writer) (*Writer, error) {
+	b := make([]byte, 24)
+	binary.LittleEndian.PutUint32(b[0:4], magicNanoseconds)
+	binary.LittleEndian.PutUint16(b[4:6], 2)
+	binary.LittleEndian.PutUint16(b[6:8], 4)
+	binary.LittleEndian.PutUint32(b[16:20], maxPacketSize)
+	binary.LittleEndian.PutUint32(b[20:24], LinkTypeEthernet)
+
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w}, nil
+}
+
+// WritePacket writes a single packet. A zero length means the length of the
+// data.
+func (w *Writer) WritePacket(p *Packet) error {
+	if p.LinkType != 0 && p.LinkType != LinkTypeEthernet {
+		return ErrLinkType
+	}
+	if len(p.Data) > maxPacketSize {
+		return ErrInvalid
+	}
+
+	length := p.Length
+	if length < len(p.Data) {
+		length = len(p.Data)
+	}
+
+	b := make([]byte, 16+len(p.Data))
+	binary.LittleEndian.PutUint32(b[0:4], uint32(p.Timestamp.Unix()))
+	binary.LittleEndian.PutUint32(b[4:8], uint32(p.Timestamp.Nanosecond()))
+	binary.LittleEndian.PutUint32(b[8:12], uint32(len(p.Data)))
+	binary.LittleEndian.PutUint32(b[12:16], uint32(length))
+	copy(b[16:], p.Data)
+
+	_, err := w.w.Write(b)
+	return err
+}
+
+// unexpected turns io.EOF in the middle of a packet into
+// io.ErrUnexpectedEOF.
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
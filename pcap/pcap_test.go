@@ -0,0 +1,130 @@
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var packets = []*Packet{
+	{
+		Timestamp: time.Unix(1600000000, 123456789),
+		Length:    4,
+		LinkType:  LinkTypeEthernet,
+		Data:      []byte{0x01, 0x02, 0x03, 0x04},
+	},
+	{
+		Timestamp: time.Unix(1600000001, 0),
+		Length:    64,
+		LinkType:  LinkTypeEthernet,
+		Data:      []byte{0x05, 0x06, 0x07},
+	},
+}
+
+func readAll(t *testing.T, r PacketReader) []*Packet {
+	var ps []*Packet
+	for {
+		p, err := r.ReadPacket()
+		if err == io.EOF {
+			return ps
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ps = append(ps, p)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range packets {
+		if err := w.WritePacket(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := NewPacketReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.(*Reader); !ok {
+		t.Fatalf("expected %T; got %T", &Reader{}, r)
+	}
+	if diff := cmp.Diff(packets, readAll(t, r)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestReaderBigEndianMicroseconds(t *testing.T) {
+	b := make([]byte, 24+16+2)
+	binary.BigEndian.PutUint32(b[0:4], magicMicroseconds)
+	binary.BigEndian.PutUint16(b[4:6], 2)
+	binary.BigEndian.PutUint16(b[6:8], 4)
+	binary.BigEndian.PutUint32(b[16:20], 65535)
+	binary.BigEndian.PutUint32(b[20:24], LinkTypeEthernet)
+	binary.BigEndian.PutUint32(b[24:28], 10)
+	binary.BigEndian.PutUint32(b[28:32], 500)
+	binary.BigEndian.PutUint32(b[32:36], 2)
+	binary.BigEndian.PutUint32(b[36:40], 60)
+	b[40], b[41] = 0xaa, 0xbb
+
+	r, err := NewPacketReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*Packet{
+		{
+			Timestamp: time.Unix(10, 500000),
+			Length:    60,
+			LinkType:  LinkTypeEthernet,
+			Data:      []byte{0xaa, 0xbb},
+		},
+	}
+	if diff := cmp.Diff(expected, readAll(t, r)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestReaderTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WritePacket(packets[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReadPacket(); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected %v; got %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestNewPacketReaderFormat(t *testing.T) {
+	_, err := NewPacketReader(bytes.NewReader([]byte{0x01, 0x02, 0x03, 0x04}))
+	if err != ErrFormat {
+		t.Errorf("expected %v; got %v", ErrFormat, err)
+	}
+}
+
+func TestWriterLinkType(t *testing.T) {
+	w, err := NewWriter(io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WritePacket(&Packet{LinkType: 105}); err != ErrLinkType {
+		t.Errorf("expected %v; got %v", ErrLinkType, err)
+	}
+}
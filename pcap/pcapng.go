@@ -0,0 +1,368 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"time"
+)
+
+// pcapng block types.
+const (
+	blockSection         = magicSection
+	blockInterface       = 0x00000001
+	blockPacket          = 0x00000002
+	blockSimplePacket    = 0x00000003
+	blockEnhancedPacket  = 0x00000006
+	byteOrderMagic       = 0x1a2b3c4d
+	optionEnd            = 0
+	optionTimeResolution = 9
+)
+
+// maxBlockSize limits the size of a single block.
+const maxBlockSize = maxPacketSize + 1024
+
+// ngInterface is an interface description of a section.
+type ngInterface struct {
+	linkType uint16
+	snapLen  uint32
+	// units is the number of timestamp units per second.
+	units uint64
+}
+
+// NgReader reads pcapng files. It supports multiple sections and
+// interfaces. Blocks other than packet blocks are skipped.
+type NgReader struct {
+	r          io.Reader
+	order      binary.ByteOrder
+	interfaces []ngInterface
+}
+
+var _ PacketReader = &NgReader{}
+
+// NewNgReader reads the first section header and returns a new reader.
+func NewNgReader(r io.Reader) (*NgReader, error) {
+	nr := &NgReader{r: r}
+
+	typ, body, err := nr.readBlock()
+	if err != nil {
+		return nil, err
+	}
+	if typ != blockSection {
+		return nil, ErrFormat
+	}
+	if err := nr.section(body); err != nil {
+		return nil, err
+	}
+	return nr, nil
+}
+
+// ReadPacket reads the next packet.
+func (r *NgReader) ReadPacket() (*Packet, error) {
+	for {
+		typ, body, err := r.readBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		switch typ {
+		case blockSection:
+			if err := r.section(body); err != nil {
+				return nil, err
+			}
+		case blockInterface:
+			if err := r.iface(body); err != nil {
+				return nil, err
+			}
+		case blockEnhancedPacket:
+			return r.enhancedPacket(body)
+		case blockPacket:
+			return r.packet(body)
+		case blockSimplePacket:
+			return r.simplePacket(body)
+		}
+	}
+}
+
+// readBlock reads a single block and returns its type and body. The byte
+// order of a section header is determined from its body.
+func (r *NgReader) readBlock() (uint32, []byte, error) {
+	b := make([]byte, 12)
+	if _, err := io.ReadFull(r.r, b[:8]); err != nil {
+		return 0, nil, err
+	}
+
+	order := r.order
+	if binary.LittleEndian.Uint32(b[0:4]) == blockSection {
+		if _, err := io.ReadFull(r.r, b[8:12]); err != nil {
+			return 0, nil, unexpected(err)
+		}
+		switch {
+		case binary.LittleEndian.Uint32(b[8:12]) == byteOrderMagic:
+			order = binary.LittleEndian
+		case binary.BigEndian.Uint32(b[8:12]) == byteOrderMagic:
+			order = binary.BigEndian
+		default:
+			return 0, nil, ErrInvalid
+		}
+		r.order = order
+	}
+
+	if order == nil {
+		return 0, nil, ErrFormat
+	}
+
+	typ := order.Uint32(b[0:4])
+	length := order.Uint32(b[4:8])
+	if length < 12 || length%4 != 0 || length > maxBlockSize {
+		return 0, nil, ErrInvalid
+	}
+
+	rest := make([]byte, length-8)
+	read := 0
+	if typ == blockSection {
+		read = copy(rest, b[8:12])
+	}
+	if _, err := io.ReadFull(r.r, rest[read:]); err != nil {
+		return 0, nil, unexpected(err)
+	}
+	if order.Uint32(rest[len(rest)-4:]) != length {
+		return 0, nil, ErrInvalid
+	}
+	return typ, rest[:len(rest)-4], nil
+}
+
+// section starts a new section.
+func (r *NgReader) section(body []byte) error {
+	if len(body) < 16 {
+		return ErrInvalid
+	}
+	r.interfaces = nil
+	return nil
+}
+
+// iface adds an interface description.
+func (r *NgReader) iface(body []byte) error {
+	if len(body) < 8 {
+		return ErrInvalid
+	}
+	i := ngInterface{
+		linkType: r.order.Uint16(body[0:2]),
+		snapLen:  r.order.Uint32(body[4:8]),
+		units:    1000000,
+	}
+
+	for _, o := range r.options(body[8:]) {
+		if o.code == optionTimeResolution && len(o.value) == 1 {
+			v := o.value[0]
+			base := uint64(10)
+			if v&0x80 != 0 {
+				base = 2
+			}
+			units := uint64(1)
+			for n := v & 0x7f; n > 0; n-- {
+				hi, lo := bits.Mul64(units, base)
+				if hi != 0 {
+					return ErrInvalid
+				}
+				units = lo
+			}
+			i.units = units
+		}
+	}
+
+	r.interfaces = append(r.interfaces, i)
+	return nil
+}
+
+// enhancedPacket decodes an enhanced packet block.
+func (r *NgReader) enhancedPacket(body []byte) (*Packet, error) {
+	if len(body) < 20 {
+		return nil, ErrInvalid
+	}
+	i, err := r.interfaceByID(r.order.Uint32(body[0:4]))
+	if err != nil {
+		return nil, err
+	}
+	ts := uint64(r.order.Uint32(body[4:8]))<<32 | uint64(r.order.Uint32(body[8:12]))
+	captured := r.order.Uint32(body[12:16])
+	length := r.order.Uint32(body[16:20])
+	if uint64(captured) > uint64(len(body)-20) {
+		return nil, ErrInvalid
+	}
+
+	return &Packet{
+		Timestamp: i.timestamp(ts),
+		Length:    int(length),
+		LinkType:  i.linkType,
+		Data:      append([]byte(nil), body[20:20+captured]...),
+	}, nil
+}
+
+// packet decodes an obsolete packet block.
+func (r *NgReader) packet(body []byte) (*Packet, error) {
+	if len(body) < 20 {
+		return nil, ErrInvalid
+	}
+	i, err := r.interfaceByID(uint32(r.order.Uint16(body[0:2])))
+	if err != nil {
+		return nil, err
+	}
+	ts := uint64(r.order.Uint32(body[4:8]))<<32 | uint64(r.order.Uint32(body[8:12]))
+	captured := r.order.Uint32(body[12:16])
+	length := r.order.Uint32(body[16:20])
+	if uint64(captured) > uint64(len(body)-20) {
+		return nil, ErrInvalid
+	}
+
+	return &Packet{
+		Timestamp: i.timestamp(ts),
+		Length:    int(length),
+		LinkType:  i.linkType,
+		Data:      append([]byte(nil), body[20:20+captured]...),
+	}, nil
+}
+
+// simplePacket decodes a simple packet block. It belongs to the first
+// interface and has no timestamp.
+func (r *NgReader) simplePacket(body []byte) (*Packet, error) {
+	if len(body) < 4 {
+		return nil, ErrInvalid
+	}
+	i, err := r.interfaceByID(0)
+	if err != nil {
+		return nil, err
+	}
+	length := r.order.Uint32(body[0:4])
+	captured := uint32(len(body) - 4)
+	if length < captured {
+		captured = length
+	}
+	if i.snapLen != 0 && i.snapLen < captured {
+		captured = i.snapLen
+	}
+
+	return &Packet{
+		Length:   int(length),
+		LinkType: i.linkType,
+		Data:     append([]byte(nil), body[4:4+captured]...),
+	}, nil
+}
+
+func (r *NgReader) interfaceByID(id uint32) (ngInterface, error) {
+	if uint64(id) >= uint64(len(r.interfaces)) {
+		return ngInterface{}, ErrInvalid
+	}
+	return r.interfaces[id], nil
+}
+
+// timestamp converts a timestamp in interface units.
+func (i ngInterface) timestamp(ts uint64) time.Time {
+	sec := ts / i.units
+	frac := ts % i.units
+	// frac is smaller than units so the quotient fits
+	hi, lo := bits.Mul64(frac, uint64(time.Second))
+	nsec, _ := bits.Div64(hi, lo, i.units)
+	return time.Unix(int64(sec), int64(nsec))
+}
+
+type ngOption struct {
+	code  uint16
+	value []byte
+}
+
+// options decodes the options of a block. Malformed options end the list.
+func (r *NgReader) options(b []byte) []ngOption {
+	var options []ngOption
+	for len(b) >= 4 {
+		code := r.order.Uint16(b[0:2])
+		length := int(r.order.Uint16(b[2:4]))
+		if code == optionEnd || 4+length > len(b) {
+			break
+		}
+		options = append(options, ngOption{code: code, value: b[4 : 4+length]})
+		b = b[4+pad4(length):]
+	}
+	return options
+}
+
+// NgWriter writes pcapng files with a single section and a single ethernet
+// interface with a resolution of nanoseconds. Every block is a single write
+// to the underlying writer.
+type NgWriter struct {
+	w io.Writer
+}
+
+var _ PacketWriter = &NgWriter{}
+
+// NewNgWriter writes the section header and the interface description and
+// returns a new writer.
+func NewNgWriter(w io.Writer) (*NgWriter, error) {
+	nw := &NgWriter{w: w}
+
+	shb := make([]byte, 16)
+	binary.LittleEndian.PutUint32(shb[0:4], byteOrderMagic)
+	binary.LittleEndian.PutUint16(shb[4:6], 1)
+	binary.LittleEndian.PutUint16(shb[6:8], 0)
+	binary.LittleEndian.PutUint64(shb[8:16], ^uint64(0))
+	if err := nw.writeBlock(blockSection, shb); err != nil {
+		return nil, err
+	}
+
+	idb := make([]byte, 20)
+	binary.LittleEndian.PutUint16(idb[0:2], LinkTypeEthernet)
+	binary.LittleEndian.PutUint32(idb[4:8], maxPacketSize)
+	binary.LittleEndian.PutUint16(idb[8:10], optionTimeResolution)
+	binary.LittleEndian.PutUint16(idb[10:12], 1)
+	idb[12] = 9
+	// padding and end of options
+	if err := nw.writeBlock(blockInterface, idb); err != nil {
+		return nil, err
+	}
+	return nw, nil
+}
+
+// WritePacket writes a single packet as enhanced packet block. A zero
+// length means the length of the data.
+func (w *NgWriter) WritePacket(p *Packet) error {
+	if p.LinkType != 0 && p.LinkType != LinkTypeEthernet {
+		return ErrLinkType
+	}
+	if len(p.Data) > maxPacketSize {
+		return ErrInvalid
+	}
+
+	length := p.Length
+	if length < len(p.Data) {
+		length = len(p.Data)
+	}
+
+	ts := uint64(p.Timestamp.UnixNano())
+	body := make([]byte, 20+pad4(len(p.Data)))
+	binary.LittleEndian.PutUint32(body[4:8], uint32(ts>>32))
+	binary.LittleEndian.PutUint32(body[8:12], uint32(ts))
+	binary.LittleEndian.PutUint32(body[12:16], uint32(len(p.Data)))
+	binary.LittleEndian.PutUint32(body[16:20], uint32(length))
+	copy(body[20:], p.Data)
+
+	return w.writeBlock(blockEnhancedPacket, body)
+}
+
+// writeBlock writes a block with the given body. The body must be padded to
+// four bytes.
+func (w *NgWriter) writeBlock(typ uint32, body []byte) error {
+	length := uint32(12 + len(body))
+	b := make([]byte, length)
+	binary.LittleEndian.PutUint32(b[0:4], typ)
+	binary.LittleEndian.PutUint32(b[4:8], length)
+	copy(b[8:], body)
+	binary.LittleEndian.PutUint32(b[length-4:], length)
+
+	_, err := w.w.Write(b)
+	return err
+}
+
+// pad4 returns n rounded up to a multiple of four.
+func pad4(n int) int {
+	return (n + 3) &^ 3
+}
@@ -0,0 +1,160 @@
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNgWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewNgWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range packets {
+		if err := w.WritePacket(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := NewPacketReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.(*NgReader); !ok {
+		t.Fatalf("expected %T; got %T", &NgReader{}, r)
+	}
+	if diff := cmp.Diff(packets, readAll(t, r)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+// ngBlock returns a big endian block.
+func ngBlock(typ uint32, body []byte) []byte {
+	b := make([]byte, 12+len(body))
+	binary.BigEndian.PutUint32(b[0:4], typ)
+	binary.BigEndian.PutUint32(b[4:8], uint32(len(b)))
+	copy(b[8:], body)
+	binary.BigEndian.PutUint32(b[len(b)-4:], uint32(len(b)))
+	return b
+}
+
+func TestNgReaderBigEndian(t *testing.T) {
+	shb := make([]byte, 16)
+	binary.BigEndian.PutUint32(shb[0:4], byteOrderMagic)
+	binary.BigEndian.PutUint16(shb[4:6], 1)
+	binary.BigEndian.PutUint64(shb[8:16], ^uint64(0))
+
+	// microseconds by default
+	idb1 := make([]byte, 8)
+	binary.BigEndian.PutUint16(idb1[0:2], LinkTypeEthernet)
+
+	// milliseconds
+	idb2 := make([]byte, 20)
+	binary.BigEndian.PutUint16(idb2[0:2], LinkTypeEthernet)
+	binary.BigEndian.PutUint16(idb2[8:10], optionTimeResolution)
+	binary.BigEndian.PutUint16(idb2[10:12], 1)
+	idb2[12] = 3
+
+	epb := func(id uint32, ts uint64, data []byte) []byte {
+		b := make([]byte, 20+pad4(len(data)))
+		binary.BigEndian.PutUint32(b[0:4], id)
+		binary.BigEndian.PutUint32(b[4:8], uint32(ts>>32))
+		binary.BigEndian.PutUint32(b[8:12], uint32(ts))
+		binary.BigEndian.PutUint32(b[12:16], uint32(len(data)))
+		binary.BigEndian.PutUint32(b[16:20], uint32(len(data)))
+		copy(b[20:], data)
+		return b
+	}
+
+	spb := make([]byte, 8)
+	binary.BigEndian.PutUint32(spb[0:4], 3)
+	copy(spb[4:], []byte{0x07, 0x08, 0x09})
+
+	var buf bytes.Buffer
+	buf.Write(ngBlock(blockSection, shb))
+	buf.Write(ngBlock(blockInterface, idb1))
+	buf.Write(ngBlock(blockInterface, idb2))
+	// name resolution block is skipped
+	buf.Write(ngBlock(0x00000004, make([]byte, 4)))
+	buf.Write(ngBlock(blockEnhancedPacket, epb(0, 1500000, []byte{0x01})))
+	buf.Write(ngBlock(blockEnhancedPacket, epb(1, 2500, []byte{0x02, 0x03})))
+	buf.Write(ngBlock(blockSimplePacket, spb))
+
+	r, err := NewPacketReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*Packet{
+		{
+			Timestamp: time.Unix(1, 500000000),
+			Length:    1,
+			LinkType:  LinkTypeEthernet,
+			Data:      []byte{0x01},
+		},
+		{
+			Timestamp: time.Unix(2, 500000000),
+			Length:    2,
+			LinkType:  LinkTypeEthernet,
+			Data:      []byte{0x02, 0x03},
+		},
+		{
+			Length:   3,
+			LinkType: LinkTypeEthernet,
+			Data:     []byte{0x07, 0x08, 0x09},
+		},
+	}
+	if diff := cmp.Diff(expected, readAll(t, r)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestNgReaderInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewNgWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WritePacket(packets[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	// corrupt the trailing length of the packet block
+	b := buf.Bytes()
+	b[len(b)-1] = 0xff
+
+	r, err := NewNgReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReadPacket(); err != ErrInvalid {
+		t.Errorf("expected %v; got %v", ErrInvalid, err)
+	}
+}
+
+func TestNgReaderUnknownInterface(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := NewNgWriter(&buf); err != nil {
+		t.Fatal(err)
+	}
+	body := make([]byte, 20)
+	binary.LittleEndian.PutUint32(body[0:4], 1)
+	b := make([]byte, 12+len(body))
+	binary.LittleEndian.PutUint32(b[0:4], blockEnhancedPacket)
+	binary.LittleEndian.PutUint32(b[4:8], uint32(len(b)))
+	copy(b[8:], body)
+	binary.LittleEndian.PutUint32(b[len(b)-4:], uint32(len(b)))
+	buf.Write(b)
+
+	r, err := NewNgReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReadPacket(); err != ErrInvalid {
+		t.Errorf("expected %v; got %v", ErrInvalid, err)
+	}
+}
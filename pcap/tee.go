@@ -0,0 +1,69 @@
+package pcap
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zemirco/dcp"
+)
+
+// Tee is a transport that records all frames sent and received over another
+// transport. Use it with dcp.NewConn.
+type Tee struct {
+	dcp.Transport
+
+	mu  sync.Mutex
+	w   PacketWriter
+	err error
+}
+
+var _ dcp.Transport = &Tee{}
+
+// NewTee returns a new transport that records all frames of t to w.
+func NewTee(t dcp.Transport, w PacketWriter) *Tee {
+	return &Tee{
+		Transport: t,
+		w:         w,
+	}
+}
+
+// ReadPacket reads a single ethernet frame and records it.
+func (t *Tee) ReadPacket(b []byte) (int, error) {
+	n, err := t.Transport.ReadPacket(b)
+	if err == nil {
+		t.record(b[:n])
+	}
+	return n, err
+}
+
+// WritePacket writes a single ethernet frame and records it.
+func (t *Tee) WritePacket(b []byte) error {
+	err := t.Transport.WritePacket(b)
+	if err == nil {
+		t.record(b)
+	}
+	return err
+}
+
+// Err returns the first error recording a frame. Recording stops after an
+// error while the transport keeps working.
+func (t *Tee) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+func (t *Tee) record(b []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.err != nil {
+		return
+	}
+	t.err = t.w.WritePacket(&Packet{
+		Timestamp: time.Now(),
+		Length:    len(b),
+		LinkType:  LinkTypeEthernet,
+		Data:      append([]byte(nil), b...),
+	})
+}
@@ -0,0 +1,67 @@
+package pcap
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/zemirco/dcp"
+	"github.com/zemirco/dcp/hub"
+)
+
+func TestTee(t *testing.T) {
+	h := hub.New(nil)
+	e1, err := h.Endpoint(mac1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e2, err := h.Endpoint(mac2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e2.Close()
+
+	var buf bytes.Buffer
+	w, err := NewNgWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tee := NewTee(e1, w)
+	conn := dcp.NewConn(tee)
+	defer conn.Close()
+	peer := dcp.NewConn(e2)
+
+	if err := conn.WriteFrame(dcp.NewSignalRequest(mac2, mac1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := peer.WriteFrame(dcp.NewFactoryResetRequest(mac1, mac2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tee.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.ReadFrame(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tee.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewPacketReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames, err := ReadFrames(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 2 {
+		t.Fatalf("expected %d; got %d", 2, len(frames))
+	}
+	if frames[0].Source.String() != mac1.String() {
+		t.Errorf("expected %s; got %s", mac1, frames[0].Source)
+	}
+	if frames[1].Source.String() != mac2.String() {
+		t.Errorf("expected %s; got %s", mac2, frames[1].Source)
+	}
+}
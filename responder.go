@@ -0,0 +1,443 @@
+package dcp
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/zemirco/dcp/block"
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// Profile is the state of an emulated device.
+type Profile struct {
+	NameOfStation     string
+	AliasName         string
+	DeviceVendorValue string
+
+	VendorID           uint16
+	DeviceID           uint16
+	DeviceInstanceHigh uint8
+	DeviceInstanceLow  uint8
+
+	Role block.Role
+
+	// Options are the options and suboptions the device announces in its
+	// device options block. Nil means all blocks the responder supports.
+	Options []block.Pair
+
+	IPAddress       net.IP
+	Subnetmask      net.IP
+	StandardGateway net.IP
+	DNSServers      []net.IP
+}
+
+// Responder answers identify, get and set requests like a device does.
+// Set requests update its profile.
+type Responder struct {
+	conn *Conn
+
+	mu          sync.Mutex
+	profile     Profile
+	inOperation bool
+	ignoreGet   bool
+
+	writeMu sync.Mutex
+}
+
+// NewResponder returns a new responder for the given profile. Its mac
+// address is the hardware address of the connection.
+func NewResponder(conn *Conn, p Profile) *Responder {
+	return &Responder{
+		conn:    conn,
+		profile: p,
+	}
+}
+
+// Profile returns the current profile of the device.
+func (r *Responder) Profile() Profile {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.profile
+}
+
+// SetInOperation makes the responder reject all set requests except signal
+// with an in operation error, like a device that exchanges data with a
+// controller. It is safe to call while Serve is running.
+func (r *Responder) SetInOperation(v bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inOperation = v
+}
+
+// SetIgnoreGet makes the responder drop all get requests without an answer.
+// It is safe to call while Serve is running.
+func (r *Responder) SetIgnoreGet(v bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ignoreGet = v
+}
+
+// Serve answers requests until the context is canceled or reading from the
+// connection fails. It never returns on its own while the connection works,
+// so callers stop it with the context. The returned error is the context
+// error or the read error. Identify responses are delayed as requested by the
+// response delay of the request, see IdentifyResponseDelay. Errors writing
+// responses are only reported to the tracer.
+func (r *Responder) Serve(ctx context.Context) error {
+	stop, fromContext, err := deadline(ctx, r.conn, time.Time{})
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		b, err := r.conn.read()
+		if err != nil {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			// the read deadline may fire right before the context notices
+			// that its deadline passed
+			if isTimeout(err) && fromContext {
+				return context.DeadlineExceeded
+			}
+			return err
+		}
+
+		// ignore malformed frames from other stations
+		request, err := r.conn.decode(b)
+		if err != nil {
+			continue
+		}
+
+		if request.ServiceType != Request || bytes.Equal(request.Source, r.conn.HardwareAddr()) {
+			continue
+		}
+
+		switch {
+		case request.FrameID == IdentifyRequest && request.ServiceID == Identify:
+			if !bytes.Equal(request.Destination, identifyMulticast) && !bytes.Equal(request.Destination, r.conn.HardwareAddr()) {
+				continue
+			}
+			response := r.identify(request)
+			if response == nil {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				t := time.NewTimer(IdentifyResponseDelay(r.conn.HardwareAddr(), request.ResponseDelay))
+				defer t.Stop()
+				select {
+				case <-ctx.Done():
+				case <-t.C:
+					r.write(response)
+				}
+			}()
+		case request.FrameID == GetSet && bytes.Equal(request.Destination, r.conn.HardwareAddr()):
+			switch request.ServiceID {
+			case Get:
+				response := r.get(request)
+				if response == nil {
+					continue
+				}
+				r.write(response)
+			case Set:
+				r.write(r.set(request))
+			}
+		}
+	}
+}
+
+// write sends a single response.
+func (r *Responder) write(f *Frame) {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	r.conn.WriteFrame(f)
+}
+
+// identify returns the identify response or nil if the device does not match
+// the filter of the request.
+func (r *Responder) identify(request *Frame) *Frame {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.matches(request.Blocks) {
+		return nil
+	}
+
+	blocks := make([]block.Block, 0, len(identifyBlocks)+1)
+	for _, s := range identifyBlocks {
+		if b := r.block(s.Option, s.Suboption); b != nil {
+			blocks = append(blocks, b)
+		}
+	}
+	if r.profile.AliasName != "" {
+		blocks = append(blocks, r.block(option.Properties, suboption.AliasName))
+	}
+	return r.response(request, IdentifyResponse, blocks)
+}
+
+// matches reports whether the profile matches all filter blocks. Unknown
+// filter blocks never match.
+func (r *Responder) matches(filter []block.Block) bool {
+	p := r.profile
+	for _, b := range filter {
+		switch b := b.(type) {
+		case *block.All:
+		case *block.NameOfStation:
+			if b.NameOfStation != p.NameOfStation {
+				return false
+			}
+		case *block.AliasName:
+			if p.AliasName == "" || b.AliasName != p.AliasName {
+				return false
+			}
+		case *block.ManufacturerSpecific:
+			if b.DeviceVendorValue != p.DeviceVendorValue {
+				return false
+			}
+		case *block.DeviceID:
+			if b.VendorID != p.VendorID || b.DeviceID != p.DeviceID {
+				return false
+			}
+		case *block.DeviceInstance:
+			if b.DeviceInstanceHigh != p.DeviceInstanceHigh || b.DeviceInstanceLow != p.DeviceInstanceLow {
+				return false
+			}
+		case *block.DeviceRole:
+			if b.Role&p.Role == 0 {
+				return false
+			}
+		case *block.IPParameter:
+			if !b.IPAddress.Equal(ipOrZero(p.IPAddress)) ||
+				!b.Subnetmask.Equal(ipOrZero(p.Subnetmask)) ||
+				!b.StandardGateway.Equal(ipOrZero(p.StandardGateway)) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// get returns the get response with the selected blocks or nil if get
+// requests are ignored. Unsupported blocks are answered with a control
+// response.
+func (r *Responder) get(request *Frame) *Frame {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ignoreGet {
+		return nil
+	}
+
+	blocks := make([]block.Block, 0, len(request.Blocks))
+	for _, b := range request.Blocks {
+		opt, subopt := blockType(b)
+		if rb := r.block(opt, subopt); rb != nil {
+			blocks = append(blocks, rb)
+			continue
+		}
+		blocks = append(blocks, block.NewControlResponseWithError(opt, subopt, notSupported(opt)))
+	}
+	return r.response(request, GetSet, blocks)
+}
+
+// set applies the blocks of the request and returns the set response with
+// one control response per block. Like a device, the responder applies each
+// block on its own: blocks before a failing block stay applied and their
+// control responses report no error.
+func (r *Responder) set(request *Frame) *Frame {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	blocks := make([]block.Block, 0, len(request.Blocks))
+	for _, b := range request.Blocks {
+		opt, subopt := blockType(b)
+		blocks = append(blocks, block.NewControlResponseWithError(opt, subopt, r.apply(b)))
+	}
+	return r.response(request, GetSet, blocks)
+}
+
+// apply applies a single block of a set request to the profile.
+func (r *Responder) apply(b block.Block) block.BlockError {
+	if _, ok := b.(*block.Signal); !ok && r.inOperation {
+		return block.InOperation
+	}
+
+	p := &r.profile
+	switch b := b.(type) {
+	case *block.Control:
+		switch b.Suboption {
+		case suboption.Start, suboption.Stop:
+		case suboption.FactoryReset:
+			r.reset()
+		case suboption.ResetToFactory:
+			switch block.ResetMode(b.Qualifier) {
+			case block.ResetCommunication, block.ResetAllData, block.ResetDevice:
+				r.reset()
+			}
+		}
+		return block.NoError
+	case *block.Signal:
+		return block.NoError
+	case *block.NameOfStation:
+		p.NameOfStation = b.NameOfStation
+		return block.NoError
+	case *block.IPParameter:
+		p.IPAddress = copyIP(b.IPAddress)
+		p.Subnetmask = copyIP(b.Subnetmask)
+		p.StandardGateway = copyIP(b.StandardGateway)
+		return block.NoError
+	case *block.FullIPSuite:
+		p.IPAddress = copyIP(b.IPAddress)
+		p.Subnetmask = copyIP(b.Subnetmask)
+		p.StandardGateway = copyIP(b.StandardGateway)
+		p.DNSServers = nil
+		for _, dns := range b.DNSServers {
+			if dns != nil && !dns.IsUnspecified() {
+				p.DNSServers = append(p.DNSServers, copyIP(dns))
+			}
+		}
+		return block.NoError
+	}
+	opt, _ := blockType(b)
+	return notSupported(opt)
+}
+
+// reset resets the communication parameters to their factory values.
+func (r *Responder) reset() {
+	r.profile.NameOfStation = ""
+	r.profile.IPAddress = nil
+	r.profile.Subnetmask = nil
+	r.profile.StandardGateway = nil
+	r.profile.DNSServers = nil
+}
+
+// block returns the block with the current value for option and suboption or
+// nil if the responder does not support it.
+func (r *Responder) block(opt option.Option, subopt suboption.Suboption) block.Block {
+	p := r.profile
+	switch opt {
+	case option.IP:
+		switch subopt {
+		case suboption.MACAddress:
+			return block.NewMACAddressWithInfo(r.conn.HardwareAddr(), 0)
+		case suboption.IPParameter:
+			return block.NewIPParameterWithInfo(ipOrZero(p.IPAddress), ipOrZero(p.Subnetmask), ipOrZero(p.StandardGateway), ipInfo(p))
+		case suboption.FullIPSuite:
+			return block.NewFullIPSuiteWithInfo(ipOrZero(p.IPAddress), ipOrZero(p.Subnetmask), ipOrZero(p.StandardGateway), p.DNSServers, ipInfo(p))
+		}
+	case option.Properties:
+		switch subopt {
+		case suboption.ManufacturerSpecific:
+			return block.NewManufacturerSpecificWithInfo(0, p.DeviceVendorValue)
+		case suboption.NameOfStation:
+			return block.NewNameOfStationWithInfo(0, p.NameOfStation)
+		case suboption.DeviceID:
+			return block.NewDeviceIDWithInfo(0, p.VendorID, p.DeviceID)
+		case suboption.DeviceRole:
+			return block.NewDeviceRoleWithInfo(p.Role, 0)
+		case suboption.DeviceOptions:
+			options := p.Options
+			if options == nil {
+				options = supportedOptions
+			}
+			return block.NewDeviceOptionsWithInfo(options, 0)
+		case suboption.AliasName:
+			if p.AliasName != "" {
+				return block.NewAliasNameWithInfo(0, p.AliasName)
+			}
+		case suboption.DeviceInstance:
+			return block.NewDeviceInstanceWithInfo(0, p.DeviceInstanceHigh, p.DeviceInstanceLow)
+		}
+	}
+	return nil
+}
+
+// response returns a response to request with the given blocks.
+func (r *Responder) response(request *Frame, frameID FrameID, blocks []block.Block) *Frame {
+	t := Telegram{
+		FrameID:     frameID,
+		ServiceID:   request.ServiceID,
+		ServiceType: Response,
+		XID:         request.XID,
+		Blocks:      blocks,
+	}
+	t.DCPDataLength = uint16(t.dataLen())
+
+	return &Frame{
+		EthernetII: *NewEthernetII(request.Source, r.conn.HardwareAddr()),
+		Telegram:   t,
+	}
+}
+
+// identifyBlocks are the blocks of an identify response.
+var identifyBlocks = []block.Pair{
+	{Option: option.Properties, Suboption: suboption.DeviceOptions},
+	{Option: option.Properties, Suboption: suboption.ManufacturerSpecific},
+	{Option: option.Properties, Suboption: suboption.NameOfStation},
+	{Option: option.Properties, Suboption: suboption.DeviceID},
+	{Option: option.Properties, Suboption: suboption.DeviceRole},
+	{Option: option.Properties, Suboption: suboption.DeviceInstance},
+	{Option: option.IP, Suboption: suboption.IPParameter},
+}
+
+// supportedOptions are all blocks a responder supports.
+var supportedOptions = []block.Pair{
+	{Option: option.IP, Suboption: suboption.MACAddress},
+	{Option: option.IP, Suboption: suboption.IPParameter},
+	{Option: option.IP, Suboption: suboption.FullIPSuite},
+	{Option: option.Properties, Suboption: suboption.ManufacturerSpecific},
+	{Option: option.Properties, Suboption: suboption.NameOfStation},
+	{Option: option.Properties, Suboption: suboption.DeviceID},
+	{Option: option.Properties, Suboption: suboption.DeviceRole},
+	{Option: option.Properties, Suboption: suboption.DeviceOptions},
+	{Option: option.Properties, Suboption: suboption.AliasName},
+	{Option: option.Properties, Suboption: suboption.DeviceInstance},
+	{Option: option.Control, Suboption: suboption.Start},
+	{Option: option.Control, Suboption: suboption.Stop},
+	{Option: option.Control, Suboption: suboption.Signal},
+	{Option: option.Control, Suboption: suboption.FactoryReset},
+	{Option: option.Control, Suboption: suboption.ResetToFactory},
+}
+
+// notSupported returns the error code for an unsupported block.
+func notSupported(opt option.Option) block.BlockError {
+	switch opt {
+	case option.IP, option.Properties, option.DHCP, option.Control, option.Initiative:
+		return block.SuboptionNotSupported
+	}
+	return block.OptionNotSupported
+}
+
+// ipInfo returns the block info of ip blocks. It tells whether the ip
+// address is set.
+func ipInfo(p Profile) uint16 {
+	if p.IPAddress == nil || p.IPAddress.IsUnspecified() {
+		return 0x0000
+	}
+	return 0x0001
+}
+
+// ipOrZero returns ip or 0.0.0.0 if ip is nil.
+func ipOrZero(ip net.IP) net.IP {
+	if ip == nil {
+		return net.IPv4zero
+	}
+	return ip
+}
+
+// copyIP returns a copy of ip that does not share memory with a frame.
+func copyIP(ip net.IP) net.IP {
+	return append(net.IP(nil), ip...)
+}
@@ -0,0 +1,329 @@
+package dcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/zemirco/dcp/block"
+	"github.com/zemirco/dcp/hub"
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// link forwards all frames written to one transport to the other one.
+func link(a, b *fakeTransport, done chan struct{}) {
+	forward := func(from, to *fakeTransport) {
+		for {
+			select {
+			case <-done:
+				return
+			case p := <-from.out:
+				to.in <- p
+			}
+		}
+	}
+	go forward(a, b)
+	go forward(b, a)
+}
+
+var testProfile = Profile{
+	NameOfStation:     "zeiss",
+	DeviceVendorValue: "ET200SP",
+	VendorID:          0x002a,
+	DeviceID:          0x010a,
+	Role:              block.RoleIODevice,
+	IPAddress:         net.IP{172, 19, 104, 5},
+	Subnetmask:        net.IP{255, 255, 0, 0},
+	StandardGateway:   net.IP{0, 0, 0, 0},
+}
+
+// serve starts a responder with the test profile and returns a client
+// connected to it.
+func serve(t *testing.T) (*Client, *Responder, net.HardwareAddr) {
+	device := net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
+	ftc := newFakeTransport(net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21})
+	ftr := newFakeTransport(device)
+
+	done := make(chan struct{})
+	link(ftc, ftr, done)
+
+	r := NewResponder(NewConn(ftr), testProfile)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- r.Serve(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		if err := <-served; err != context.Canceled {
+			t.Errorf("expected %v; got %v", context.Canceled, err)
+		}
+		close(done)
+	})
+
+	return NewClient(NewConn(ftc)), r, device
+}
+
+func TestResponderIdentify(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter []block.Block
+		found  bool
+	}{
+		{
+			name:  "all",
+			found: true,
+		},
+		{
+			name:   "name of station",
+			filter: []block.Block{block.NewNameOfStationFilter("zeiss")},
+			found:  true,
+		},
+		{
+			name:   "other name of station",
+			filter: []block.Block{block.NewNameOfStationFilter("other")},
+		},
+		{
+			name: "device id and ip parameter",
+			filter: []block.Block{
+				block.NewDeviceIDFilter(0x002a, 0x010a),
+				block.NewIPParameterFilter(net.IP{172, 19, 104, 5}, net.IP{255, 255, 0, 0}, net.IP{0, 0, 0, 0}),
+			},
+			found: true,
+		},
+		{
+			name:   "alias name",
+			filter: []block.Block{block.NewAliasNameFilter("port-001.switch")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _, device := serve(t)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			devices, err := c.Identify(ctx, &IdentifyOptions{
+				ResponseDelay: 1,
+				Filter:        tt.filter,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.found {
+				if len(devices) != 0 {
+					t.Errorf("expected %d; got %d", 0, len(devices))
+				}
+				return
+			}
+			if len(devices) != 1 {
+				t.Fatalf("expected %d; got %d", 1, len(devices))
+			}
+			if devices[0].MAC.String() != device.String() {
+				t.Errorf("expected %s; got %s", device, devices[0].MAC)
+			}
+			response := devices[0].Response
+			if response.NameOfStation().NameOfStation != "zeiss" {
+				t.Errorf("expected %s; got %s", "zeiss", response.NameOfStation().NameOfStation)
+			}
+			if response.ManufacturerSpecific().DeviceVendorValue != "ET200SP" {
+				t.Errorf("expected %s; got %s", "ET200SP", response.ManufacturerSpecific().DeviceVendorValue)
+			}
+			if !response.DeviceRole().IsDevice() {
+				t.Error("expected io device")
+			}
+			if !response.DeviceOptions().Supports(option.Properties, suboption.NameOfStation) {
+				t.Error("expected name of station to be supported")
+			}
+		})
+	}
+}
+
+func TestResponderSet(t *testing.T) {
+	c, r, device := serve(t)
+	ctx := context.Background()
+
+	ip := block.NewIPParameterQualifier()
+	ip.IPAddress = net.IP{192, 168, 0, 10}
+	ip.Subnetmask = net.IP{255, 255, 255, 0}
+	ip.StandardGateway = net.IP{192, 168, 0, 1}
+
+	results, err := c.Set(ctx, device, block.NewNameOfStationQualifier(block.QualifierPermanent, "other"), ip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected %d; got %d", 4, len(results))
+	}
+
+	p := r.Profile()
+	if p.NameOfStation != "other" {
+		t.Errorf("expected %s; got %s", "other", p.NameOfStation)
+	}
+	if p.IPAddress.String() != "192.168.0.10" {
+		t.Errorf("expected %s; got %s", "192.168.0.10", p.IPAddress)
+	}
+
+	results, err = c.Get(ctx, device,
+		BlockSelector{Option: option.Properties, Suboption: suboption.NameOfStation},
+		BlockSelector{Option: option.IP, Suboption: suboption.IPParameter},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nos := results[0].Block.(*block.NameOfStation); nos.NameOfStation != "other" {
+		t.Errorf("expected %s; got %s", "other", nos.NameOfStation)
+	}
+	if ipp := results[1].Block.(*block.IPParameter); ipp.StandardGateway.String() != "192.168.0.1" {
+		t.Errorf("expected %s; got %s", "192.168.0.1", ipp.StandardGateway)
+	}
+}
+
+func TestResponderNotSupported(t *testing.T) {
+	c, _, device := serve(t)
+	ctx := context.Background()
+
+	_, err := c.Get(ctx, device, BlockSelector{Option: option.DHCP, Suboption: suboption.HostName})
+	if !errors.Is(err, block.ErrSuboptionNotSupported) {
+		t.Errorf("expected %v; got %v", block.ErrSuboptionNotSupported, err)
+	}
+
+	_, err = c.Set(ctx, device, block.NewDHCPClientIdentifierQualifier(block.QualifierPermanent, block.ClientIdentifierNameOfStation, nil))
+	if !errors.Is(err, block.ErrSuboptionNotSupported) {
+		t.Errorf("expected %v; got %v", block.ErrSuboptionNotSupported, err)
+	}
+
+	_, err = c.Get(ctx, device, BlockSelector{Option: 0x81, Suboption: 0x01})
+	if !errors.Is(err, block.ErrOptionNotSupported) {
+		t.Errorf("expected %v; got %v", block.ErrOptionNotSupported, err)
+	}
+}
+
+func TestResponderSetPartial(t *testing.T) {
+	c, r, device := serve(t)
+	ctx := context.Background()
+
+	results, err := c.Set(ctx, device,
+		block.NewNameOfStationQualifier(block.QualifierPermanent, "other"),
+		block.NewDHCPClientIdentifierQualifier(block.QualifierPermanent, block.ClientIdentifierNameOfStation, nil),
+	)
+	if !errors.Is(err, block.ErrSuboptionNotSupported) {
+		t.Errorf("expected %v; got %v", block.ErrSuboptionNotSupported, err)
+	}
+	// results include the start and stop blocks of the transaction
+	if len(results) != 4 {
+		t.Fatalf("expected %d; got %d", 4, len(results))
+	}
+
+	// the name of station is applied although the dhcp block fails
+	if results[1].Err != nil {
+		t.Errorf("expected no error for name of station; got %v", results[1].Err)
+	}
+	if p := r.Profile(); p.NameOfStation != "other" {
+		t.Errorf("expected %s; got %s", "other", p.NameOfStation)
+	}
+	if !errors.Is(results[2].Err, block.ErrSuboptionNotSupported) {
+		t.Errorf("expected %v; got %v", block.ErrSuboptionNotSupported, results[2].Err)
+	}
+}
+
+func TestResponderInOperation(t *testing.T) {
+	c, r, device := serve(t)
+	r.SetInOperation(true)
+	ctx := context.Background()
+
+	err := c.SetNameOfStation(ctx, device, "other", false)
+	if !errors.Is(err, block.ErrInOperation) {
+		t.Errorf("expected %v; got %v", block.ErrInOperation, err)
+	}
+	if p := r.Profile(); p.NameOfStation != "zeiss" {
+		t.Errorf("expected %s; got %s", "zeiss", p.NameOfStation)
+	}
+	if err := c.Signal(ctx, device); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestResponderIgnoreGet(t *testing.T) {
+	c, r, device := serve(t)
+	r.SetIgnoreGet(true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Get(ctx, device, BlockSelector{Option: option.Properties, Suboption: suboption.NameOfStation})
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected %v; got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestResponderFactoryReset(t *testing.T) {
+	c, r, device := serve(t)
+
+	if err := c.FactoryReset(context.Background(), device, "zeiss"); err != nil {
+		t.Fatal(err)
+	}
+
+	p := r.Profile()
+	if p.NameOfStation != "" {
+		t.Errorf("expected empty name of station; got %s", p.NameOfStation)
+	}
+	if p.IPAddress != nil {
+		t.Errorf("expected no ip address; got %s", p.IPAddress)
+	}
+}
+
+func TestResponderHub(t *testing.T) {
+	h := hub.New(&hub.Options{
+		Latency: time.Millisecond,
+		Jitter:  5 * time.Millisecond,
+		Seed:    1,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for i := 0; i < 5; i++ {
+		e, err := h.Endpoint(net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x00, byte(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := testProfile
+		p.NameOfStation = fmt.Sprintf("device-%d", i)
+		go NewResponder(NewConn(e), p).Serve(ctx)
+	}
+
+	e, err := h.Endpoint(net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(NewConn(e))
+
+	devices, err := c.Identify(ctx, &IdentifyOptions{ResponseDelay: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 5 {
+		t.Fatalf("expected %d; got %d", 5, len(devices))
+	}
+
+	if err := c.SetNameOfStation(ctx, devices[0].MAC, "renamed", false); err != nil {
+		t.Fatal(err)
+	}
+	devices, err = c.Identify(ctx, &IdentifyOptions{
+		ResponseDelay: 1,
+		Filter:        []block.Block{block.NewNameOfStationFilter("renamed")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 1 {
+		t.Fatalf("expected %d; got %d", 1, len(devices))
+	}
+}
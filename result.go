@@ -0,0 +1,99 @@
+package dcp
+
+import (
+	"github.com/zemirco/dcp/block"
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// Result is the outcome of a single block of a request.
+type Result struct {
+	Option    option.Option
+	Suboption suboption.Suboption
+
+	// Block is the block returned by a get request.
+	Block block.Block
+
+	Err error
+}
+
+// setResults matches the control responses of a set response to the blocks
+// of the request. There is one result per request block.
+func setResults(request, response *Frame) []Result {
+	responses := response.ControlResponses()
+	used := make([]bool, len(responses))
+
+	results := make([]Result, len(request.Blocks))
+	for i, b := range request.Blocks {
+		opt, subopt := blockType(b)
+		results[i] = Result{
+			Option:    opt,
+			Suboption: subopt,
+			Err:       ErrNoControlResponse,
+		}
+		for j, cr := range responses {
+			if !used[j] && cr.Response == opt && cr.Suboption == subopt {
+				used[j] = true
+				results[i].Err = cr.Err()
+				break
+			}
+		}
+	}
+	return results
+}
+
+// getResults matches the blocks of a get response to the selectors of the
+// request. There is one result per selector.
+func getResults(request, response *Frame) []Result {
+	used := make([]bool, len(response.Blocks))
+
+	results := make([]Result, len(request.Blocks))
+	for i, b := range request.Blocks {
+		opt, subopt := blockType(b)
+		results[i] = Result{
+			Option:    opt,
+			Suboption: subopt,
+			Err:       ErrBlockMissing,
+		}
+		for j, rb := range response.Blocks {
+			if used[j] {
+				continue
+			}
+			if cr, ok := rb.(*block.ControlResponse); ok {
+				if cr.Response == opt && cr.Suboption == subopt && cr.Err() != nil {
+					used[j] = true
+					results[i].Err = cr.Err()
+					break
+				}
+				continue
+			}
+			if ropt, rsubopt := blockType(rb); ropt == opt && rsubopt == subopt {
+				used[j] = true
+				results[i].Block = rb
+				results[i].Err = nil
+				break
+			}
+		}
+	}
+	return results
+}
+
+// firstErr returns the first error of all results.
+func firstErr(results []Result) error {
+	for _, r := range results {
+		if r.Err != nil {
+			return r.Err
+		}
+	}
+	return nil
+}
+
+// blockType returns option and suboption of a block. Blocks that do not
+// implement block.Typer, like registered vendor blocks without a block header
+// of this package, report option and suboption 0.
+func blockType(b block.Block) (option.Option, suboption.Suboption) {
+	if t, ok := b.(block.Typer); ok {
+		return t.Type()
+	}
+	return 0, 0
+}
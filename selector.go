@@ -0,0 +1,43 @@
+package dcp
+
+import (
+	"github.com/zemirco/dcp/block"
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// BlockSelector selects a block in a get request.
+type BlockSelector struct {
+	Option    option.Option
+	Suboption suboption.Suboption
+}
+
+var (
+	_ block.Block = &BlockSelector{}
+	_ block.Typer = &BlockSelector{}
+)
+
+// Type returns option and suboption of the selected block.
+func (s *BlockSelector) Type() (option.Option, suboption.Suboption) {
+	return s.Option, s.Suboption
+}
+
+// UnmarshalBinary turns bytes into struct.
+func (s *BlockSelector) UnmarshalBinary(b []byte) error {
+	if len(b) < s.Len() {
+		return ErrTruncated
+	}
+	s.Option = option.Option(b[0])
+	s.Suboption = suboption.Suboption(b[1])
+	return nil
+}
+
+// MarshalBinary converts struct into byte slice.
+func (s *BlockSelector) MarshalBinary() ([]byte, error) {
+	return []byte{byte(s.Option), byte(s.Suboption)}, nil
+}
+
+// Len returns length for block selector.
+func (s *BlockSelector) Len() int {
+	return 2
+}
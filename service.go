@@ -8,6 +8,7 @@ const (
 	Get      ServiceID = 1
 	Set      ServiceID = 4
 	Identify ServiceID = 5
+	Hello    ServiceID = 6
 )
 
 // ServiceType is a single byte.
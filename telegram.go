@@ -16,27 +16,38 @@ type Telegram struct {
 	ServiceType   ServiceType
 	XID           uint32
 	ResponseDelay uint16
+
+	// DCPDataLength is set by UnmarshalBinary. MarshalBinary always writes
+	// the length of all blocks including padding.
 	DCPDataLength uint16
 
-	// blocks
-	All                  *block.All
-	NameOfStation        *block.NameOfStation
-	IPParameter          *block.IPParameter
-	DeviceInstance       *block.DeviceInstance
-	ManufacturerSpecific *block.ManufacturerSpecific
-	DeviceInitiative     *block.DeviceInitiative
-	ControlResponse      *block.ControlResponse
+	// Blocks in the order they appear on the wire.
+	Blocks []block.Block
 }
 
 var _ block.Block = &Telegram{}
 
 // UnmarshalBinary unmarshals a byte slice into a EthernetII.
 func (t *Telegram) UnmarshalBinary(b []byte) error {
+	return t.unmarshalBinary(b, defaultTracer())
+}
+
+func (t *Telegram) unmarshalBinary(b []byte, trace Tracer) error {
+	if len(b) < 12 {
+		return ErrTruncated
+	}
+
 	i := 0
 
 	t.FrameID = FrameID(binary.BigEndian.Uint16(b[i : i+2]))
 	i += 2
 
+	switch t.FrameID {
+	case IdentifyRequest, IdentifyResponse, GetSet, HelloRequest:
+	default:
+		return &FrameIDError{FrameID: t.FrameID}
+	}
+
 	t.ServiceID = ServiceID(b[i])
 	i++
 
@@ -52,21 +63,45 @@ func (t *Telegram) UnmarshalBinary(b []byte) error {
 	t.DCPDataLength = binary.BigEndian.Uint16(b[i : i+2])
 	i += 2
 
-	length := int(t.DCPDataLength)
-	offset := 0
+	if int(t.DCPDataLength) > len(b)-i {
+		return &LengthError{
+			DCPDataLength: t.DCPDataLength,
+			Available:     len(b) - i,
+		}
+	}
+
+	data := b[i : i+int(t.DCPDataLength)]
 
-	// fmt.Println("####", length)
+	t.Blocks = nil
 
-	for length > 0 {
-		blockLength := t.decodeBlock(b[i+offset:])
+	// get requests only carry option and suboption of the requested blocks
+	if t.ServiceID == Get && t.ServiceType == Request {
+		return t.decodeSelectors(data, trace)
+	}
+
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return ErrTruncated
+		}
 
-		// add padding for odd length block
-		if blockLength%2 != 0 {
-			blockLength++
+		blockLength := 4 + int(binary.BigEndian.Uint16(data[2:4]))
+		if blockLength > len(data) {
+			return ErrTruncated
 		}
 
-		length -= blockLength
-		offset += blockLength
+		offset := int(t.DCPDataLength) - len(data)
+		if err := t.decodeBlock(data[:blockLength], offset, trace); err != nil {
+			return err
+		}
+		data = data[blockLength:]
+
+		// skip padding for odd length block
+		if blockLength%2 != 0 && len(data) > 0 {
+			if data[0] != 0 {
+				return ErrPadding
+			}
+			data = data[1:]
+		}
 	}
 
 	return nil
@@ -74,15 +109,11 @@ func (t *Telegram) UnmarshalBinary(b []byte) error {
 
 // MarshalBinary converts struct into byte slice.
 func (t *Telegram) MarshalBinary() ([]byte, error) {
-	size := 12
-	if t.All != nil {
-		size += t.All.Len()
-	}
-	if t.IPParameter != nil {
-		size += t.IPParameter.Len()
-	}
+	return t.marshalBinary(defaultTracer())
+}
 
-	b := make([]byte, size)
+func (t *Telegram) marshalBinary(trace Tracer) ([]byte, error) {
+	b := make([]byte, t.Len())
 	i := 0
 
 	binary.BigEndian.PutUint16(b[i:i+2], uint16(t.FrameID))
@@ -100,24 +131,27 @@ func (t *Telegram) MarshalBinary() ([]byte, error) {
 	binary.BigEndian.PutUint16(b[i:i+2], t.ResponseDelay)
 	i += 2
 
-	binary.BigEndian.PutUint16(b[i:i+2], t.DCPDataLength)
+	binary.BigEndian.PutUint16(b[i:i+2], uint16(t.dataLen()))
 	i += 2
 
-	if t.All != nil {
-		allBytes, err := t.All.MarshalBinary()
-		if err != nil {
-			return b, err
+	for _, blk := range t.Blocks {
+		blockBytes, err := blk.MarshalBinary()
+		e := Event{
+			Kind:  BlockEncoded,
+			Block: blk,
+			Err:   err,
 		}
-		copy(b[i:], allBytes)
-		i += t.All.Len()
-	}
-	if t.IPParameter != nil {
-		ipBytes, err := t.IPParameter.MarshalBinary()
+		if len(blockBytes) >= 4 {
+			e.Option = option.Option(blockBytes[0])
+			e.Suboption = suboption.Suboption(blockBytes[1])
+			e.Length = binary.BigEndian.Uint16(blockBytes[2:4])
+		}
+		trace.trace(e)
 		if err != nil {
 			return b, err
 		}
-		copy(b[i:], ipBytes)
-		i += t.IPParameter.Len()
+		copy(b[i:], blockBytes)
+		i += paddedLen(blk)
 	}
 
 	return b, nil
@@ -125,91 +159,249 @@ func (t *Telegram) MarshalBinary() ([]byte, error) {
 
 // Len returns length.
 func (t *Telegram) Len() int {
-	length := 12
-	if t.IPParameter != nil {
-		length += t.IPParameter.Len()
+	return 12 + t.dataLen()
+}
+
+// dataLen returns the length of all blocks including padding.
+func (t *Telegram) dataLen() int {
+	length := 0
+	for _, blk := range t.Blocks {
+		length += paddedLen(blk)
 	}
-	if t.All != nil {
-		length += t.All.Len()
+	return length
+}
+
+// paddedLen returns the block length with a padding byte for odd lengths.
+func paddedLen(b block.Block) int {
+	length := b.Len()
+	if length%2 != 0 {
+		length++
 	}
 	return length
 }
 
-func (t *Telegram) decodeBlock(b []byte) int {
+// decodeBlock decodes the block b found at offset in the DCP data.
+func (t *Telegram) decodeBlock(b []byte, offset int, trace Tracer) error {
 	opt := option.Option(b[0])
-	fmt.Println("option", opt)
-
 	subopt := suboption.Suboption(b[1])
-	fmt.Println("suboption", subopt)
-
 	length := binary.BigEndian.Uint16(b[2:4])
-	fmt.Println("length", length)
 
-	hasInfo := t.ServiceID == Identify && t.ServiceType == Response
-	// hasQualifier := false
+	// control responses in get and set responses have no block info, hello
+	// requests announce the device like an identify response
+	hasInfo := ((t.ServiceID == Identify || t.ServiceID == Get) && t.ServiceType == Response &&
+		!(opt == option.Control && subopt == suboption.Response)) ||
+		(t.ServiceID == Hello && t.ServiceType == Request)
+	hasQualifier := t.ServiceID == Set && t.ServiceType == Request
 
-	switch {
+	blk, ok := block.New(opt, subopt, hasInfo, hasQualifier)
+	if !ok {
+		blk = block.NewRaw(hasInfo, hasQualifier)
+	}
+	err := blk.UnmarshalBinary(b)
+	trace.trace(Event{
+		Kind:      BlockDecoded,
+		Option:    opt,
+		Suboption: subopt,
+		Length:    length,
+		Block:     blk,
+		Err:       err,
+	})
+	if err != nil {
+		return fmt.Errorf("dcp: block at offset %d option %#02x suboption %#02x: %w", offset, opt, subopt, err)
+	}
+	t.Blocks = append(t.Blocks, blk)
 
-	case opt == option.Properties && subopt == suboption.NameOfStation:
+	return nil
+}
 
-		t.NameOfStation = block.NewNameOfStation(hasInfo)
-		if err := t.NameOfStation.UnmarshalBinary(b); err != nil {
-			panic(err)
+func (t *Telegram) decodeSelectors(b []byte, trace Tracer) error {
+	for len(b) > 0 {
+		s := &BlockSelector{}
+		err := s.UnmarshalBinary(b)
+		trace.trace(Event{
+			Kind:      BlockDecoded,
+			Option:    s.Option,
+			Suboption: s.Suboption,
+			Block:     s,
+			Err:       err,
+		})
+		if err != nil {
+			return err
 		}
-		fmt.Printf("%#v\n", t.NameOfStation)
-		fmt.Println(t.NameOfStation.NameOfStation)
+		t.Blocks = append(t.Blocks, s)
+		b = b[s.Len():]
+	}
+	return nil
+}
 
-	case opt == option.IP && subopt == suboption.IPParameter:
+// All returns the first all block or nil.
+func (t *Telegram) All() *block.All {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.All); ok {
+			return b
+		}
+	}
+	return nil
+}
 
-		t.IPParameter = &block.IPParameter{}
-		t.IPParameter.HasInfo = hasInfo
-		if err := t.IPParameter.UnmarshalBinary(b); err != nil {
-			panic(err)
+// NameOfStation returns the first name of station block or nil.
+func (t *Telegram) NameOfStation() *block.NameOfStation {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.NameOfStation); ok {
+			return b
 		}
+	}
+	return nil
+}
 
-		fmt.Printf("%#v\n", t.IPParameter)
-		fmt.Println(t.IPParameter.IPAddress, t.IPParameter.Subnetmask, t.IPParameter.StandardGateway)
+// MACAddress returns the first mac address block or nil.
+func (t *Telegram) MACAddress() *block.MACAddress {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.MACAddress); ok {
+			return b
+		}
+	}
+	return nil
+}
 
-	case opt == option.Properties && subopt == suboption.DeviceInstance:
+// IPParameter returns the first ip parameter block or nil.
+func (t *Telegram) IPParameter() *block.IPParameter {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.IPParameter); ok {
+			return b
+		}
+	}
+	return nil
+}
+
+// FullIPSuite returns the first full ip suite block or nil.
+func (t *Telegram) FullIPSuite() *block.FullIPSuite {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.FullIPSuite); ok {
+			return b
+		}
+	}
+	return nil
+}
 
-		t.DeviceInstance = block.NewDeviceInstance(hasInfo)
-		if err := t.DeviceInstance.UnmarshalBinary(b); err != nil {
-			panic(err)
+// DeviceID returns the first device id block or nil.
+func (t *Telegram) DeviceID() *block.DeviceID {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.DeviceID); ok {
+			return b
 		}
+	}
+	return nil
+}
 
-		fmt.Printf("%#v\n", t.DeviceInstance)
-		fmt.Println(t.DeviceInstance.DeviceInstanceHigh, t.DeviceInstance.DeviceInstanceLow)
+// DeviceRole returns the first device role block or nil.
+func (t *Telegram) DeviceRole() *block.DeviceRole {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.DeviceRole); ok {
+			return b
+		}
+	}
+	return nil
+}
 
-	case opt == option.Properties && subopt == suboption.ManufacturerSpecific:
+// DeviceOptions returns the first device options block or nil.
+func (t *Telegram) DeviceOptions() *block.DeviceOptions {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.DeviceOptions); ok {
+			return b
+		}
+	}
+	return nil
+}
 
-		t.ManufacturerSpecific = block.NewManufacturerSpecific(hasInfo)
-		if err := t.ManufacturerSpecific.UnmarshalBinary(b); err != nil {
-			panic(err)
+// AliasName returns the first alias name block or nil.
+func (t *Telegram) AliasName() *block.AliasName {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.AliasName); ok {
+			return b
 		}
+	}
+	return nil
+}
 
-		fmt.Printf("%#v\n", t.ManufacturerSpecific)
-		fmt.Println(t.ManufacturerSpecific.DeviceVendorValue)
+// OEMDeviceID returns the first oem device id block or nil.
+func (t *Telegram) OEMDeviceID() *block.OEMDeviceID {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.OEMDeviceID); ok {
+			return b
+		}
+	}
+	return nil
+}
 
-	case opt == option.Initiative && subopt == suboption.DeviceInitiative:
+// DHCP returns the first dhcp block with the given suboption or nil.
+func (t *Telegram) DHCP(subopt suboption.Suboption) *block.DHCP {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.DHCP); ok && b.Suboption == subopt {
+			return b
+		}
+	}
+	return nil
+}
 
-		t.DeviceInitiative = block.NewDeviceInitiative(hasInfo)
-		if err := t.DeviceInitiative.UnmarshalBinary(b); err != nil {
-			panic(err)
+// DeviceInstance returns the first device instance block or nil.
+func (t *Telegram) DeviceInstance() *block.DeviceInstance {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.DeviceInstance); ok {
+			return b
 		}
+	}
+	return nil
+}
 
-		fmt.Printf("%#v\n", t.DeviceInitiative)
-		fmt.Println(t.DeviceInitiative.Value)
+// ManufacturerSpecific returns the first manufacturer specific block or nil.
+func (t *Telegram) ManufacturerSpecific() *block.ManufacturerSpecific {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.ManufacturerSpecific); ok {
+			return b
+		}
+	}
+	return nil
+}
 
-	case opt == option.Control && subopt == suboption.Response:
+// DeviceInitiative returns the first device initiative block or nil.
+func (t *Telegram) DeviceInitiative() *block.DeviceInitiative {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.DeviceInitiative); ok {
+			return b
+		}
+	}
+	return nil
+}
 
-		t.ControlResponse = block.NewControlResponse(hasInfo)
-		if err := t.ControlResponse.UnmarshalBinary(b); err != nil {
-			panic(err)
+// ControlResponse returns the first control response block or nil.
+func (t *Telegram) ControlResponse() *block.ControlResponse {
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.ControlResponse); ok {
+			return b
 		}
+	}
+	return nil
+}
 
-		fmt.Printf("%#v\n", t.ControlResponse)
-		fmt.Println(t.ControlResponse.Error)
+// ControlResponses returns all control response blocks.
+func (t *Telegram) ControlResponses() []*block.ControlResponse {
+	var blocks []*block.ControlResponse
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.ControlResponse); ok {
+			blocks = append(blocks, b)
+		}
 	}
+	return blocks
+}
 
-	return 1 + 1 + 2 + int(length)
+// Raw returns all blocks without a registered block type.
+func (t *Telegram) Raw() []*block.Raw {
+	var blocks []*block.Raw
+	for _, b := range t.Blocks {
+		if b, ok := b.(*block.Raw); ok {
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks
 }
@@ -0,0 +1,330 @@
+package dcp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zemirco/dcp/block"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// identify response with manufacturer specific, name of station, device id,
+// device instance, ip parameter and device initiative blocks
+var identifyResponseTelegram = []byte{
+	0xfe, 0xff, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+	0x00, 0x00, 0x00, 0x46, 0x02, 0x01, 0x00, 0x09,
+	0x00, 0x00, 0x45, 0x54, 0x32, 0x30, 0x30, 0x53,
+	0x50, 0x00, 0x02, 0x02, 0x00, 0x07, 0x00, 0x00,
+	0x7a, 0x65, 0x69, 0x73, 0x73, 0x00, 0x02, 0x03,
+	0x00, 0x06, 0x00, 0x00, 0x00, 0x2a, 0x01, 0x0a,
+	0x02, 0x07, 0x00, 0x04, 0x00, 0x00, 0x00, 0x64,
+	0x01, 0x02, 0x00, 0x0e, 0x00, 0x01, 0xac, 0x13,
+	0x68, 0x05, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x06, 0x01, 0x00, 0x04, 0x00, 0x00,
+	0x00, 0x01,
+}
+
+func TestTelegramUnmarshalBinary(t *testing.T) {
+	var tg Telegram
+	if err := tg.UnmarshalBinary(identifyResponseTelegram); err != nil {
+		t.Fatal(err)
+	}
+	if len(tg.Blocks) != 6 {
+		t.Fatalf("expected %d; got %d", 6, len(tg.Blocks))
+	}
+	if tg.ManufacturerSpecific().DeviceVendorValue != "ET200SP" {
+		t.Errorf("expected %s; got %s", "ET200SP", tg.ManufacturerSpecific().DeviceVendorValue)
+	}
+	if tg.NameOfStation().NameOfStation != "zeiss" {
+		t.Errorf("expected %s; got %s", "zeiss", tg.NameOfStation().NameOfStation)
+	}
+	if tg.DeviceID().VendorID != 0x002a {
+		t.Errorf("expected %d; got %d", 0x002a, tg.DeviceID().VendorID)
+	}
+	if tg.DeviceInstance().DeviceInstanceLow != 0x64 {
+		t.Errorf("expected %d; got %d", 0x64, tg.DeviceInstance().DeviceInstanceLow)
+	}
+	if tg.IPParameter().IPAddress.String() != "172.19.104.5" {
+		t.Errorf("expected %s; got %s", "172.19.104.5", tg.IPParameter().IPAddress)
+	}
+	if tg.DeviceInitiative().Value != 1 {
+		t.Errorf("expected %d; got %d", 1, tg.DeviceInitiative().Value)
+	}
+	if tg.ControlResponse() != nil {
+		t.Errorf("expected no control response; got %v", tg.ControlResponse())
+	}
+}
+
+// hello request with name of station and ip parameter blocks
+var helloTelegram = []byte{
+	0xfe, 0xfc, 0x06, 0x00, 0x01, 0x02, 0x03, 0x04,
+	0x00, 0x00, 0x00, 0x1e, 0x02, 0x02, 0x00, 0x07,
+	0x00, 0x00, 0x7a, 0x65, 0x69, 0x73, 0x73, 0x00,
+	0x01, 0x02, 0x00, 0x0e, 0x00, 0x01, 0xac, 0x13,
+	0x68, 0x05, 0xff, 0xff, 0x00, 0x00, 0xac, 0x13,
+	0x00, 0x01,
+}
+
+func TestTelegramHello(t *testing.T) {
+	var tg Telegram
+	if err := tg.UnmarshalBinary(helloTelegram); err != nil {
+		t.Fatal(err)
+	}
+	if tg.FrameID != HelloRequest {
+		t.Errorf("expected %#04x; got %#04x", HelloRequest, tg.FrameID)
+	}
+	if tg.NameOfStation().NameOfStation != "zeiss" {
+		t.Errorf("expected %s; got %s", "zeiss", tg.NameOfStation().NameOfStation)
+	}
+	if tg.IPParameter().IPAddress.String() != "172.19.104.5" {
+		t.Errorf("expected %s; got %s", "172.19.104.5", tg.IPParameter().IPAddress)
+	}
+
+	b, err := tg.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(b, helloTelegram); diff != "" {
+		t.Error(diff)
+	}
+}
+
+// identify response with two vendor specific blocks
+var unknownBlocksTelegram = []byte{
+	0xfe, 0xff, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+	0x00, 0x00, 0x00, 0x12, 0x81, 0x01, 0x00, 0x06,
+	0x00, 0x00, 0x02, 0x07, 0x01, 0x02, 0x82, 0x02,
+	0x00, 0x04, 0x00, 0x00, 0x02, 0x00,
+}
+
+func TestTelegramRaw(t *testing.T) {
+	var tg Telegram
+	if err := tg.UnmarshalBinary(unknownBlocksTelegram); err != nil {
+		t.Fatal(err)
+	}
+	raw := tg.Raw()
+	if len(raw) != 2 {
+		t.Fatalf("expected %d; got %d", 2, len(raw))
+	}
+	if raw[0].Option != 0x81 {
+		t.Errorf("expected %d; got %d", 0x81, raw[0].Option)
+	}
+	if diff := cmp.Diff(raw[1].Data, []byte{0x02, 0x00}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestTelegramDHCP(t *testing.T) {
+	b := []byte{
+		0xfe, 0xff, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+		0x00, 0x00, 0x00, 0x18, 0x03, 0x0c, 0x00, 0x07,
+		0x00, 0x00, 0x7a, 0x65, 0x69, 0x73, 0x73, 0x00,
+		0x03, 0x3d, 0x00, 0x08, 0x00, 0x00, 0x00, 0x7a,
+		0x65, 0x69, 0x73, 0x73,
+	}
+	var tg Telegram
+	if err := tg.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if tg.DHCP(suboption.HostName).Text() != "zeiss" {
+		t.Errorf("expected %s; got %s", "zeiss", tg.DHCP(suboption.HostName).Text())
+	}
+	if _, id, ok := tg.DHCP(suboption.DHCPClientIdentifier).ClientIdentifier(); !ok || string(id) != "zeiss" {
+		t.Errorf("expected %s; got %s", "zeiss", id)
+	}
+	if tg.DHCP(suboption.ServerIdentifier) != nil {
+		t.Errorf("expected no server identifier; got %v", tg.DHCP(suboption.ServerIdentifier))
+	}
+}
+
+func TestTelegramRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{
+			name: "identify response",
+			b:    identifyResponseTelegram,
+		},
+		{
+			name: "duplicate blocks",
+			b: []byte{
+				0xfe, 0xff, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+				0x00, 0x00, 0x00, 0x18, 0x02, 0x02, 0x00, 0x07,
+				0x00, 0x00, 0x7a, 0x65, 0x69, 0x73, 0x73, 0x00,
+				0x02, 0x02, 0x00, 0x08, 0x00, 0x00, 0x6f, 0x74,
+				0x68, 0x65, 0x72, 0x31,
+			},
+		},
+		{
+			name: "unknown blocks",
+			b:    unknownBlocksTelegram,
+		},
+		{
+			name: "set dhcp client identifier",
+			b: []byte{
+				0xfe, 0xfd, 0x04, 0x00, 0x01, 0x02, 0x03, 0x04,
+				0x00, 0x00, 0x00, 0x08, 0x03, 0x3d, 0x00, 0x03,
+				0x00, 0x01, 0x00, 0x00,
+			},
+		},
+		{
+			name: "set request",
+			b: []byte{
+				0xfe, 0xfd, 0x04, 0x00, 0x01, 0x02, 0x03, 0x04,
+				0x00, 0x00, 0x00, 0x1e, 0x02, 0x02, 0x00, 0x07,
+				0x00, 0x01, 0x7a, 0x65, 0x69, 0x73, 0x73, 0x00,
+				0x01, 0x02, 0x00, 0x0e, 0x00, 0x01, 0xac, 0x13,
+				0x68, 0x05, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tg Telegram
+			if err := tg.UnmarshalBinary(tt.b); err != nil {
+				t.Fatal(err)
+			}
+			b, err := tg.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(b, tt.b); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestTelegramMarshalBinary(t *testing.T) {
+	tg := Telegram{
+		FrameID:     IdentifyRequest,
+		ServiceID:   Identify,
+		ServiceType: Request,
+		XID:         0x01020304,
+		Blocks: []block.Block{
+			block.NewNameOfStationFilter("zeiss"),
+			block.NewNameOfStationFilter("other1"),
+		},
+	}
+	b, err := tg.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		0xfe, 0xfe, 0x05, 0x00, 0x01, 0x02, 0x03, 0x04,
+		0x00, 0x00, 0x00, 0x14, 0x02, 0x02, 0x00, 0x05,
+		0x7a, 0x65, 0x69, 0x73, 0x73, 0x00, 0x02, 0x02,
+		0x00, 0x06, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x31,
+	}
+	if diff := cmp.Diff(b, expected); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestTelegramUnmarshalBinaryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		err  error
+	}{
+		{
+			name: "short header",
+			b:    []byte{0xfe, 0xff, 0x05, 0x01},
+			err:  ErrTruncated,
+		},
+		{
+			name: "unknown frame id",
+			b: []byte{
+				0xfe, 0xfb, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			err: &FrameIDError{FrameID: 0xfefb},
+		},
+		{
+			name: "data length exceeds telegram",
+			b: []byte{
+				0xfe, 0xff, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+				0x00, 0x00, 0x00, 0x08, 0x02, 0x02, 0x00, 0x04,
+			},
+			err: &LengthError{DCPDataLength: 8, Available: 4},
+		},
+		{
+			name: "short block header",
+			b: []byte{
+				0xfe, 0xff, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+				0x00, 0x00, 0x00, 0x02, 0x02, 0x02,
+			},
+			err: ErrTruncated,
+		},
+		{
+			name: "block exceeds data length",
+			b: []byte{
+				0xfe, 0xff, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+				0x00, 0x00, 0x00, 0x06, 0x02, 0x02, 0x00, 0x07,
+				0x00, 0x00, 0x7a, 0x65, 0x69, 0x73, 0x73, 0x00,
+			},
+			err: ErrTruncated,
+		},
+		{
+			name: "block shorter than block info",
+			b: []byte{
+				0xfe, 0xff, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+				0x00, 0x00, 0x00, 0x06, 0x02, 0x02, 0x00, 0x01,
+				0x00, 0x00,
+			},
+			err: ErrTruncated,
+		},
+		{
+			name: "odd padding",
+			b: []byte{
+				0xfe, 0xff, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+				0x00, 0x00, 0x00, 0x14, 0x02, 0x02, 0x00, 0x07,
+				0x00, 0x00, 0x7a, 0x65, 0x69, 0x73, 0x73, 0xff,
+				0x02, 0x07, 0x00, 0x04, 0x00, 0x00, 0x00, 0x64,
+			},
+			err: ErrPadding,
+		},
+		{
+			name: "block length mismatch",
+			b: []byte{
+				0xfe, 0xff, 0x05, 0x01, 0x01, 0x02, 0x03, 0x04,
+				0x00, 0x00, 0x00, 0x08, 0x02, 0x07, 0x00, 0x03,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			err: &block.LengthError{Option: 0x02, Suboption: 0x07, Length: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tg Telegram
+			err := tg.UnmarshalBinary(tt.b)
+
+			switch expected := tt.err.(type) {
+			case *FrameIDError:
+				var got *FrameIDError
+				if !errors.As(err, &got) || *got != *expected {
+					t.Errorf("expected %v; got %v", expected, err)
+				}
+			case *LengthError:
+				var got *LengthError
+				if !errors.As(err, &got) || *got != *expected {
+					t.Errorf("expected %v; got %v", expected, err)
+				}
+			case *block.LengthError:
+				var got *block.LengthError
+				if !errors.As(err, &got) || *got != *expected {
+					t.Errorf("expected %v; got %v", expected, err)
+				}
+			default:
+				if !errors.Is(err, expected) {
+					t.Errorf("expected %v; got %v", expected, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package dcp
+
+import (
+	"log"
+	"sync"
+
+	"github.com/zemirco/dcp/block"
+	"github.com/zemirco/dcp/option"
+	"github.com/zemirco/dcp/suboption"
+)
+
+// EventKind identifies what happened in an event.
+type EventKind uint8
+
+// Known event kinds.
+const (
+	BlockDecoded EventKind = iota + 1
+	BlockEncoded
+	FrameRead
+	FrameWritten
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case BlockDecoded:
+		return "block decoded"
+	case BlockEncoded:
+		return "block encoded"
+	case FrameRead:
+		return "frame read"
+	case FrameWritten:
+		return "frame written"
+	}
+	return "unknown"
+}
+
+// Event is a single decode or encode event.
+type Event struct {
+	Kind EventKind
+
+	// block events
+	Option    option.Option
+	Suboption suboption.Suboption
+	Length    uint16
+	Block     block.Block
+
+	// frame events
+	Frame *Frame
+
+	// Err is set when decoding or encoding failed.
+	Err error
+}
+
+// Tracer receives events. It must not modify the block or frame.
+type Tracer func(e Event)
+
+func (t Tracer) trace(e Event) {
+	if t != nil {
+		t(e)
+	}
+}
+
+var (
+	tracerMu sync.RWMutex
+	tracer   Tracer
+)
+
+// SetTracer sets the package level tracer. It receives the events of all
+// decoding and encoding that happens without a connection specific tracer.
+// The library is silent with a nil tracer, which is the default.
+func SetTracer(t Tracer) {
+	tracerMu.Lock()
+	tracer = t
+	tracerMu.Unlock()
+}
+
+func defaultTracer() Tracer {
+	tracerMu.RLock()
+	defer tracerMu.RUnlock()
+	return tracer
+}
+
+// LogTracer returns a tracer that prints all events to l.
+func LogTracer(l *log.Logger) Tracer {
+	return func(e Event) {
+		switch {
+		case e.Kind == BlockDecoded || e.Kind == BlockEncoded:
+			if e.Err != nil {
+				l.Printf("%s: option %#02x suboption %#02x length %d: %v", e.Kind, e.Option, e.Suboption, e.Length, e.Err)
+				return
+			}
+			l.Printf("%s: option %#02x suboption %#02x length %d: %#v", e.Kind, e.Option, e.Suboption, e.Length, e.Block)
+		case e.Err != nil:
+			l.Printf("%s: %v", e.Kind, e.Err)
+		default:
+			l.Printf("%s: %#v", e.Kind, e.Frame)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package dcp
+
+import (
+	"bytes"
+	"log"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/zemirco/dcp/suboption"
+)
+
+func TestConnTracer(t *testing.T) {
+	source := net.HardwareAddr{0xa4, 0x4c, 0xc8, 0xe5, 0x47, 0x21}
+	ft := newFakeTransport(source)
+	c := NewConn(ft)
+
+	var events []Event
+	c.Tracer = func(e Event) {
+		events = append(events, e)
+	}
+
+	ft.in <- identifyResponse(source, net.HardwareAddr{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}, 1, "zeiss")
+	if _, err := c.ReadFrame(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected %d; got %d", 2, len(events))
+	}
+	if events[0].Kind != BlockDecoded {
+		t.Errorf("expected %s; got %s", BlockDecoded, events[0].Kind)
+	}
+	if events[0].Suboption != suboption.NameOfStation {
+		t.Errorf("expected %d; got %d", suboption.NameOfStation, events[0].Suboption)
+	}
+	if events[0].Length != 7 {
+		t.Errorf("expected %d; got %d", 7, events[0].Length)
+	}
+	if events[1].Kind != FrameRead {
+		t.Errorf("expected %s; got %s", FrameRead, events[1].Kind)
+	}
+
+	events = nil
+	if err := c.WriteFrame(NewIdentifyRequest(source)); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected %d; got %d", 2, len(events))
+	}
+	if events[0].Kind != BlockEncoded {
+		t.Errorf("expected %s; got %s", BlockEncoded, events[0].Kind)
+	}
+	if events[1].Kind != FrameWritten {
+		t.Errorf("expected %s; got %s", FrameWritten, events[1].Kind)
+	}
+}
+
+func TestSetTracer(t *testing.T) {
+	var buf bytes.Buffer
+	SetTracer(LogTracer(log.New(&buf, "", 0)))
+	defer SetTracer(nil)
+
+	var tg Telegram
+	if err := tg.UnmarshalBinary(identifyResponseTelegram); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected %d; got %d", 6, len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "block decoded: option 0x02 suboption 0x01 length 9") {
+		t.Errorf("unexpected log line %q", lines[0])
+	}
+}
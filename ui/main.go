@@ -1,28 +1,36 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"html/template"
 	"log"
 	"net"
 	"net/http"
-	"syscall"
+	"os"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/gorilla/mux"
 	"github.com/zemirco/dcp"
+	"github.com/zemirco/dcp/block"
+	"github.com/zemirco/dcp/pcap"
 )
 
-// host order (usually little endian) -> network order (big endian)
-func htons(n int) int {
-	return int(int16(byte(n))<<8 | int16(byte(n>>8)))
+// device is the json representation of a device for the ui.
+type device struct {
+	Source        net.HardwareAddr
+	NameOfStation *block.NameOfStation
+	AliasName     *block.AliasName
+	OEMDeviceID   *block.OEMDeviceID
+	IPParameter   *block.IPParameter
 }
 
 var (
 	t    *template.Template
-	db   = make(map[string]dcp.Frame)
+	db   = make(map[string]device)
 	last time.Time
 )
 
@@ -31,6 +39,8 @@ func init() {
 }
 
 func main() {
+	capture := flag.String("capture", "", "pcapng file to record all frames to")
+	flag.Parse()
 
 	r := mux.NewRouter()
 
@@ -60,11 +70,11 @@ func main() {
 	})
 
 	r.Methods(http.MethodPost).Path("/api/{mac}").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		var f dcp.Frame
-		if err := json.NewDecoder(r.Body).Decode(&f); err != nil {
+		var d device
+		if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
 			panic(err)
 		}
-		spew.Dump(f)
+		spew.Dump(d)
 	})
 
 	r.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -79,28 +89,28 @@ func main() {
 
 	ifname := "enxa44cc8e54721"
 
-	interf, err := net.InterfaceByName(ifname)
+	transport, err := dcp.ListenTransport(ifname)
 	if err != nil {
 		panic(err)
 	}
 
-	request := dcp.NewIdentifyRequest(interf.HardwareAddr)
-	b, err := request.MarshalBinary()
-	if err != nil {
-		panic(err)
-	}
-
-	fd, err := syscall.Socket(syscall.AF_PACKET, syscall.SOCK_RAW, htons(0x8892))
+	// record all sent and received frames for wireshark
+	if *capture != "" {
+		f, err := os.Create(*capture)
+		if err != nil {
+			panic(err)
+		}
+		defer f.Close()
 
-	if err != nil {
-		panic(err)
+		w, err := pcap.NewNgWriter(f)
+		if err != nil {
+			panic(err)
+		}
+		transport = pcap.NewTee(transport, w)
 	}
 
-	defer syscall.Close(fd)
-
-	addr := syscall.SockaddrLinklayer{
-		Ifindex: interf.Index,
-	}
+	conn := dcp.NewConn(transport)
+	defer conn.Close()
 
 	// // request block
 	// rb := block.NewIPParameterQualifier()
@@ -110,44 +120,32 @@ func main() {
 
 	// destination := []byte{0x00, 0x09, 0xe5, 0x00, 0x9a, 0x20}
 
-	// req := frame.NewSetIPParameterRequest(destination, interf.HardwareAddr, rb)
-	// b, err := req.MarshalBinary()
-	// if err != nil {
-	// 	panic(err)
-	// }
+	// req := frame.NewSetIPParameterRequest(destination, conn.HardwareAddr(), rb)
 
 	// spew.Dump(req)
-	// spew.Dump(b)
 
-	if err := syscall.Sendto(fd, b, 0, &addr); err != nil {
-		panic(err)
-	}
+	client := dcp.NewClient(conn)
 
 	last = time.Now()
 
-	// start reading incoming data
-	for {
-		buffer := make([]byte, 256)
-
-		n, _, err := syscall.Recvfrom(fd, buffer, 0)
-		if err != nil {
-			panic(err)
-		}
-
-		fmt.Println(n)
-
-		response := dcp.Frame{}
-		if err := response.UnmarshalBinary(buffer); err != nil {
-			panic(err)
-		}
-
-		spew.Dump(response)
+	devices, err := client.Identify(context.Background(), nil)
+	if err != nil {
+		panic(err)
+	}
 
-		// save device to db in case we have an answer to our identify request
-		if request.XID == response.XID {
-			db[response.Source.String()] = response
+	// save every device that answered our identify request to db
+	for _, d := range devices {
+		spew.Dump(d.Response)
+		db[d.MAC.String()] = device{
+			Source:        d.MAC,
+			NameOfStation: d.Response.NameOfStation(),
+			AliasName:     d.Response.AliasName(),
+			OEMDeviceID:   d.Response.OEMDeviceID(),
+			IPParameter:   d.Response.IPParameter(),
 		}
-
 	}
 
+	// keep serving the ui
+	select {}
+
 }
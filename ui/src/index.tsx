@@ -32,6 +32,7 @@ const App = () => {
             <th>MAC</th>
             <th>IP address</th>
             <th>Name of station</th>
+            <th>Alias name</th>
           </tr>
         </thead>
         <tbody>
@@ -47,6 +48,7 @@ const App = () => {
                 <td>{value.IPParameter.IPAddress}</td>
 
                 <td>{value.NameOfStation.NameOfStation}</td>
+                <td>{value.AliasName && value.AliasName.AliasName}</td>
               </tr>
             )
           })}